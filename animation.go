@@ -32,19 +32,127 @@ import (
 
 // An Animation is composed of a Pattern to play with a BlinkyTape
 // based on a playback speed and an number of repetitions.
+// Durations optionally holds the duration of each frame of the
+// pattern, in milliseconds. Frames without a duration, or with a
+// null one, are held for the delay derived from the speed.
+// If the animation has no pattern but an effect, the frames are
+// computed from the effect while the animation is played.
 type Animation struct {
-	Name    string  `json:"name"`
-	Repeat  int     `json:"repeat"`
-	Speed   uint    `json:"speed"`
-	Pattern Pattern `json:"pattern"`
+	Name      string        `json:"name"`
+	Repeat    int           `json:"repeat"`
+	Speed     uint          `json:"speed"`
+	Durations []uint        `json:"durations,omitempty"`
+	Effect    *EffectConfig `json:"effect,omitempty"`
+	Pattern   Pattern       `json:"pattern"`
 }
 
 // AnimationConfig represents the configuration of an Animation.
 type AnimationConfig struct {
 	// Repeat indicates how many times the pattern has to be played
 	Repeat int
-	// Delay is the duration to wait between the rendering of two frames
+	// Delay is the duration to wait between the rendering of two frames,
+	// used for the frames that don't have their own duration. If null,
+	// the delay derived from the speed of the animation is used
 	Delay time.Duration
+	// Policy indicates what to do with the frames that are late
+	Policy FramePolicy
+	// Transition is played from the current state of the LED strip
+	// to the first frame of the pattern, before the animation
+	Transition *Transition
+	// OnError indicates what to do with the frames that cannot be rendered
+	OnError ErrorPolicy
+	// Retries is the number of times a frame that cannot be
+	// rendered is retried, with the ErrorRetry policy. The delay
+	// between two attempts doubles after each failure
+	Retries uint
+}
+
+// Frame policy constants.
+const (
+	// FrameCatchUp renders the frames that are late as fast as
+	// possible, until the animation catches up its schedule.
+	FrameCatchUp FramePolicy = iota
+	// FrameDrop drops the frames whose deadline is already over
+	// when they are about to be rendered.
+	FrameDrop
+)
+
+// FramePolicy represents the policy applied to the frames of an
+// animation that cannot be rendered on time, for example because
+// the writes to the LED strip are slower than the delay.
+type FramePolicy int
+
+// Error policy constants.
+const (
+	// ErrorSkip skips the frames that cannot be rendered,
+	// and keeps playing the animation.
+	ErrorSkip ErrorPolicy = iota
+	// ErrorRetry retries to render a frame that cannot be rendered,
+	// and aborts the animation if all the retries fail.
+	ErrorRetry
+	// ErrorAbort aborts the animation as soon
+	// as a frame cannot be rendered.
+	ErrorAbort
+)
+
+// ErrorPolicy represents the policy applied to the frames of an
+// animation that cannot be rendered, for example because the LED
+// strip is unplugged. The errors are reported in the statistics
+// of the animation, and an aborted animation ends with the error.
+type ErrorPolicy int
+
+// Delay returns the delay to wait between two frames of the
+// animation, derived from its speed, or the default delay.
+func (a Animation) Delay() time.Duration {
+	if a.Speed != 0 {
+		return time.Second / time.Duration(a.Speed)
+	}
+	return AnimationDefaultDelay
+}
+
+// source returns a source of the frames of the pattern of the
+// animation, or of its effect for the given number of pixels.
+func (a Animation) source(pixelCount uint) (FrameSource, error) {
+	if len(a.Pattern) != 0 || a.Effect == nil {
+		return a.Pattern.Source(), nil
+	}
+	return a.Effect.Source(pixelCount)
+}
+
+// Source returns a source of the frames of the animation for the given
+// number of pixels. The source implements TimedFrameSource, so that the
+// durations of the frames are kept when it is played with PlaySource.
+func (a Animation) Source(pixelCount uint) (FrameSource, error) {
+	src, err := a.source(pixelCount)
+	if err != nil {
+		return nil, err
+	}
+	return &animationSource{FrameSource: src, durations: a.Durations}, nil
+}
+
+// animationSource is a FrameSource that
+// holds the durations of an animation.
+type animationSource struct {
+	FrameSource
+	durations []uint
+}
+
+func (as *animationSource) Duration(i int) time.Duration {
+	if i < len(as.durations) {
+		return time.Duration(as.durations[i]) * time.Millisecond
+	}
+	return 0
+}
+
+// frameDuration returns the duration of each frame of the animation,
+// using the given delay for the frames that don't have their own.
+func (a Animation) frameDuration(delay time.Duration) durationFunc {
+	return func(i int) time.Duration {
+		if i < len(a.Durations) && a.Durations[i] != 0 {
+			return time.Duration(a.Durations[i]) * time.Millisecond
+		}
+		return delay
+	}
 }
 
 // NewAnimationFromFile create a new Animation instance from a file.
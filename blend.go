@@ -0,0 +1,225 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Blend mode constants.
+const (
+	// BlendNormal replaces the colors by the ones of the other frame.
+	BlendNormal BlendMode = iota
+	// BlendAdd adds the colors, saturating at white.
+	BlendAdd
+	// BlendMultiply multiplies the colors, which darkens them.
+	BlendMultiply
+	// BlendScreen multiplies the inverse of the colors, which lightens them.
+	BlendScreen
+)
+
+// BlendMode represents the way the colors of two frames are combined.
+type BlendMode int
+
+var blendNames = map[BlendMode]string{
+	BlendNormal:   "normal",
+	BlendAdd:      "add",
+	BlendMultiply: "multiply",
+	BlendScreen:   "screen",
+}
+
+// String implements the fmt.Stringer interface.
+func (bm BlendMode) String() string {
+	if name, ok := blendNames[bm]; ok {
+		return name
+	}
+	return fmt.Sprintf("BlendMode(%d)", int(bm))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (bm BlendMode) MarshalText() ([]byte, error) {
+	if _, ok := blendNames[bm]; !ok {
+		return nil, fmt.Errorf("unknown blend mode %d", int(bm))
+	}
+	return []byte(bm.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (bm *BlendMode) UnmarshalText(text []byte) error {
+	for mode, name := range blendNames {
+		if name == string(text) {
+			*bm = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown blend mode %q", text)
+}
+
+// Lerp linearly interpolates the color and another one. The factor t
+// is between 0, which returns the color, and 1, which returns the other.
+func (c Color) Lerp(other Color, t float64) Color {
+	t = unit(t)
+	f := func(x, y byte) byte {
+		return byte(math.Floor(float64(x) + (float64(y)-float64(x))*t + 0.5))
+	}
+	return Color{R: f(c.R, other.R), G: f(c.G, other.G), B: f(c.B, other.B)}
+}
+
+// Scale scales the brightness of the color by a factor between 0 and 1.
+func (c Color) Scale(factor float64) Color {
+	f := func(v byte) byte {
+		return byte(math.Floor(float64(v)*unit(factor) + 0.5))
+	}
+	return Color{R: f(c.R), G: f(c.G), B: f(c.B)}
+}
+
+// Add adds the components of two colors, saturating at 255.
+func (c Color) Add(other Color) Color {
+	f := func(x, y byte) byte {
+		if s := int(x) + int(y); s < 255 {
+			return byte(s)
+		}
+		return 255
+	}
+	return Color{R: f(c.R, other.R), G: f(c.G, other.G), B: f(c.B, other.B)}
+}
+
+// Multiply multiplies the components of two colors, as if
+// they were between 0 and 1. The result is darker than both.
+func (c Color) Multiply(other Color) Color {
+	f := func(x, y byte) byte {
+		return byte((int(x)*int(y) + 127) / 255)
+	}
+	return Color{R: f(c.R, other.R), G: f(c.G, other.G), B: f(c.B, other.B)}
+}
+
+// Screen multiplies the inverse of the components of two
+// colors, and inverts the result. The result is lighter than both.
+func (c Color) Screen(other Color) Color {
+	f := func(x, y byte) byte {
+		return 255 - byte((int(255-x)*int(255-y)+127)/255)
+	}
+	return Color{R: f(c.R, other.R), G: f(c.G, other.G), B: f(c.B, other.B)}
+}
+
+// Blend combines the color with another one using the given mode.
+func (c Color) Blend(other Color, mode BlendMode) Color {
+	switch mode {
+	case BlendAdd:
+		return c.Add(other)
+	case BlendMultiply:
+		return c.Multiply(other)
+	case BlendScreen:
+		return c.Screen(other)
+	default:
+		return other
+	}
+}
+
+// Blend combines the frame with another one using the given mode,
+// and mixes the result with the frame according to the opacity
+// alpha, between 0 and 1. The pixels beyond the length of the
+// other frame are left unchanged.
+func (f Frame) Blend(other Frame, mode BlendMode, alpha float64) Frame {
+	b := f.copy()
+	for i := range b {
+		if i >= len(other) {
+			break
+		}
+		c := b[i].Color
+		b[i].Color = c.Lerp(c.Blend(other[i].Color, mode), unit(alpha))
+	}
+	return b
+}
+
+// Fill returns a frame of the same length with
+// all its pixels set to the given color.
+func (f Frame) Fill(c Color) Frame {
+	filled := make(Frame, len(f))
+	for i := range filled {
+		filled[i].Color = c
+	}
+	return filled
+}
+
+// Shift moves the pixels of the frame by n positions towards its end,
+// or towards its beginning if n is negative. The pixels vacated are black.
+func (f Frame) Shift(n int) Frame {
+	s := make(Frame, len(f))
+	for i := range f {
+		if j := i + n; j >= 0 && j < len(s) {
+			s[j] = f[i]
+		}
+	}
+	return s
+}
+
+// Rotate moves the pixels of the frame by n positions towards its end,
+// or towards its beginning if n is negative. The pixels moved past an
+// end of the frame wrap around to the other end.
+func (f Frame) Rotate(n int) Frame {
+	r := make(Frame, len(f))
+	if len(f) == 0 {
+		return r
+	}
+	n %= len(f)
+	if n < 0 {
+		n += len(f)
+	}
+	copy(r[n:], f)
+	copy(r, f[len(f)-n:])
+
+	return r
+}
+
+// Mirror returns a frame whose second half is the mirror of
+// its first half, so that it is symmetric around its middle.
+func (f Frame) Mirror() Frame {
+	m := f.copy()
+	for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
+		m[j] = m[i]
+	}
+	return m
+}
+
+// Reverse returns the pixels of the frame in the reverse order.
+func (f Frame) Reverse() Frame {
+	r := make(Frame, len(f))
+	for i, p := range f {
+		r[len(f)-1-i] = p
+	}
+	return r
+}
+
+// Fade scales the brightness of all the pixels of the
+// frame by a factor between 0 (black) and 1 (unchanged).
+func (f Frame) Fade(factor float64) Frame {
+	faded := make(Frame, len(f))
+	for i, p := range f {
+		faded[i].Color = p.Color.Scale(factor)
+	}
+	return faded
+}
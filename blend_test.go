@@ -0,0 +1,148 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColorOperations(t *testing.T) {
+	a := Color{R: 200, G: 100, B: 0}
+	b := Color{R: 100, G: 100, B: 255}
+
+	tests := []struct {
+		name string
+		got  Color
+		want Color
+	}{
+		{"Lerp(0)", a.Lerp(b, 0), a},
+		{"Lerp(1)", a.Lerp(b, 1), b},
+		{"Lerp(0.5)", a.Lerp(b, 0.5), Color{R: 150, G: 100, B: 128}},
+		{"Lerp(-1)", a.Lerp(b, -1), a},
+		{"Lerp(2)", a.Lerp(b, 2), b},
+		{"Scale", a.Scale(0.5), Color{R: 100, G: 50, B: 0}},
+		{"Add", a.Add(b), Color{R: 255, G: 200, B: 255}},
+		{"Multiply", a.Multiply(Color{R: 255, G: 128, B: 255}), Color{R: 200, G: 50, B: 0}},
+		{"Multiply black", a.Multiply(Color{}), Color{}},
+		{"Screen", a.Screen(b), Color{R: 222, G: 161, B: 255}},
+		{"Screen black", a.Screen(Color{}), a},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// testFrame returns a frame whose pixels have
+// a red component equal to their position + 1.
+func testFrame(n int) Frame {
+	f := make(Frame, n)
+	for i := range f {
+		f[i].Color.R = byte(i + 1)
+	}
+	return f
+}
+
+// reds returns the red components of the pixels of a frame.
+func reds(f Frame) []byte {
+	r := make([]byte, len(f))
+	for i, p := range f {
+		r[i] = p.Color.R
+	}
+	return r
+}
+
+func TestFrameOperations(t *testing.T) {
+	f := testFrame(4)
+
+	tests := []struct {
+		name string
+		got  Frame
+		want []byte
+	}{
+		{"Rotate(1)", f.Rotate(1), []byte{4, 1, 2, 3}},
+		{"Rotate(-1)", f.Rotate(-1), []byte{2, 3, 4, 1}},
+		{"Rotate(6)", f.Rotate(6), []byte{3, 4, 1, 2}},
+		{"Rotate(-9)", f.Rotate(-9), []byte{2, 3, 4, 1}},
+		{"Shift(2)", f.Shift(2), []byte{0, 0, 1, 2}},
+		{"Shift(-1)", f.Shift(-1), []byte{2, 3, 4, 0}},
+		{"Shift(5)", f.Shift(5), []byte{0, 0, 0, 0}},
+		{"Mirror", f.Mirror(), []byte{1, 2, 2, 1}},
+		{"Mirror odd", testFrame(5).Mirror(), []byte{1, 2, 3, 2, 1}},
+		{"Reverse", f.Reverse(), []byte{4, 3, 2, 1}},
+		{"Fill", f.Fill(Color{R: 9}), []byte{9, 9, 9, 9}},
+	}
+	for _, tt := range tests {
+		if got := reds(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := reds(f); !reflect.DeepEqual(got, []byte{1, 2, 3, 4}) {
+		t.Errorf("frame was modified: %v", got)
+	}
+	if got := Frame(nil).Rotate(3); len(got) != 0 {
+		t.Errorf("Rotate of an empty frame = %v", got)
+	}
+}
+
+func TestFrameBlend(t *testing.T) {
+	a := Frame{{Color{R: 200}}, {Color{R: 100}}, {Color{R: 50}}}
+	b := Frame{{Color{R: 100}}, {Color{R: 200}}}
+
+	tests := []struct {
+		name  string
+		mode  BlendMode
+		alpha float64
+		want  []byte
+	}{
+		{"normal", BlendNormal, 1, []byte{100, 200, 50}},
+		{"normal half", BlendNormal, 0.5, []byte{150, 150, 50}},
+		{"transparent", BlendNormal, 0, []byte{200, 100, 50}},
+		{"add", BlendAdd, 1, []byte{255, 255, 50}},
+		{"add half", BlendAdd, 0.5, []byte{228, 178, 50}},
+		{"multiply", BlendMultiply, 1, []byte{78, 78, 50}},
+		{"screen", BlendScreen, 1, []byte{222, 222, 50}},
+	}
+	for _, tt := range tests {
+		if got := reds(a.Blend(b, tt.mode, tt.alpha)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Blend %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBlendModeText(t *testing.T) {
+	for mode := range blendNames {
+		text, err := mode.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got BlendMode
+		if err := got.UnmarshalText(text); err != nil || got != mode {
+			t.Errorf("UnmarshalText(%q) = %v, %v", text, got, err)
+		}
+	}
+}
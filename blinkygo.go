@@ -28,11 +28,10 @@ package blinkygo
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"sync"
 	"time"
-
-	"github.com/ivahaev/timer"
-	"github.com/tarm/serial"
 )
 
 const (
@@ -44,6 +43,13 @@ const (
 	AnimationDefaultDelay time.Duration = 75 * time.Millisecond
 )
 
+// Bounds of the delay to wait before retrying to render a frame,
+// which doubles after each failure.
+const (
+	minRetryBackoff = 10 * time.Millisecond
+	maxRetryBackoff = time.Second
+)
+
 // Status constants.
 const (
 	// StatusStopped means no animation is running.
@@ -52,54 +58,118 @@ const (
 	StatusRunning
 	// StatusPaused means a running animation is paused.
 	StatusPaused
+	// StatusStreaming means frames are being streamed.
+	StatusStreaming
 )
 
 // AnimationStatus represents the animation loop status
 // of a BlinkyTape instance.
 type AnimationStatus int
 
+var statusNames = map[AnimationStatus]string{
+	StatusStopped:   "stopped",
+	StatusRunning:   "running",
+	StatusPaused:    "paused",
+	StatusStreaming: "streaming",
+}
+
+// String implements the fmt.Stringer interface.
+func (as AnimationStatus) String() string {
+	if name, ok := statusNames[as]; ok {
+		return name
+	}
+	return fmt.Sprintf("AnimationStatus(%d)", int(as))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (as AnimationStatus) MarshalText() ([]byte, error) {
+	if _, ok := statusNames[as]; !ok {
+		return nil, fmt.Errorf("unknown animation status %d", int(as))
+	}
+	return []byte(as.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (as *AnimationStatus) UnmarshalText(text []byte) error {
+	for status, name := range statusNames {
+		if name == string(text) {
+			*as = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown animation status %q", text)
+}
+
 // A BlinkyTape represents a BlinkyTape LED strip.
 // All operations that modify the state of the strip are buffered.
 type BlinkyTape struct {
-	serial               *serial.Port
+	transport            Transport
 	currState, nextState []Pixel
 	buffer               bytes.Buffer
-	stop, pause, resume  chan struct{}
 	position             uint
+	session              *session
 	status               AnimationStatus
+	eventHandler         EventHandler
+	lut                  *colorLUT
+	brightness           float64
+	mask                 []float64
+	limit                *PowerLimit
 	mutex                sync.Mutex
+	writeMutex           sync.Mutex
+	stateMutex           sync.Mutex
+	reconnect            *ReconnectConfig
+	reconnecting         bool
+	lastRender           []byte
+	closed               chan struct{}
+	closeOnce            sync.Once
+	stats                AnimationStats
+	streamStats          StreamStats
+	statsMutex           sync.Mutex
 
 	// PixelCount is the number of pixels the LED strip was initialized with.
 	PixelCount uint
 }
 
-// NewBlinkyTape creates a new BlinkyTape instance.
+// NewBlinkyTape creates a new BlinkyTape instance that communicates
+// with the LED strip through the serial port with the given name.
 // The led strip is created with all pixels set to black.
 func NewBlinkyTape(portName string, count uint) (*BlinkyTape, error) {
 	if count == 0 {
 		return nil, ErrNoPixels
 	}
-
-	config := &serial.Config{
-		Name:        portName,
-		Baud:        115200,
-		ReadTimeout: time.Millisecond * 500,
+	transport, err := NewSerialTransport(portName)
+	if err != nil {
+		return nil, err
 	}
-	port, err := serial.OpenPort(config)
+	blinky, err := NewBlinkyTapeWithTransport(transport, count)
 	if err != nil {
+		transport.Close()
 		return nil, err
 	}
+	return blinky, nil
+}
 
+// NewBlinkyTapeWithTransport creates a new BlinkyTape instance that
+// communicates with the LED strip through the given transport.
+// The led strip is created with all pixels set to black, and
+// with the default color correction.
+func NewBlinkyTapeWithTransport(t Transport, count uint) (*BlinkyTape, error) {
+	if t == nil {
+		return nil, ErrNilTransport
+	}
+	if count == 0 {
+		return nil, ErrNoPixels
+	}
 	blinky := &BlinkyTape{
-		serial:     port,
+		transport:  t,
 		currState:  make([]Pixel, count),
 		nextState:  make([]Pixel, count),
-		pause:      make(chan struct{}),
-		resume:     make(chan struct{}),
-		stop:       make(chan struct{}),
 		position:   0,
 		PixelCount: count,
 		status:     StatusStopped,
+		closed:     make(chan struct{}),
+		lut:        DefaultColorCorrection().lut(),
+		brightness: 1,
 	}
 
 	// send the control header after initializtion to stop any pattern
@@ -110,49 +180,96 @@ func NewBlinkyTape(portName string, count uint) (*BlinkyTape, error) {
 	return blinky, nil
 }
 
-// Close closes the serial port.
+// Close closes the transport of the LED strip.
+// It also stops its automatic reconnection.
 func (bt *BlinkyTape) Close() error {
 	bt.Stop()
-	return bt.serial.Close()
+
+	bt.writeMutex.Lock()
+	bt.closeOnce.Do(func() { close(bt.closed) })
+	reconnecting := bt.reconnecting
+	bt.writeMutex.Unlock()
+
+	// a broken transport is already closed
+	if reconnecting {
+		return nil
+	}
+	return bt.getTransport().Close()
+}
+
+// getTransport returns the transport of the LED
+// strip, which is replaced when it reconnects.
+func (bt *BlinkyTape) getTransport() Transport {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	return bt.transport
 }
 
 // Render sends all accumulated pixel data followed by a control byte
 // to the LED strip to render a new state. It also reset the internal
 // buffer and reset the next position to 0.
 func (bt *BlinkyTape) Render() error {
-	if bt.IsRunning() {
+	return bt.RenderContext(context.Background())
+}
+
+// RenderContext is like Render, but the write to the LED strip is
+// aborted when the context is done, in which case ctx.Err() is returned.
+func (bt *BlinkyTape) RenderContext(ctx context.Context) error {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
-	return bt.render()
+	return bt.render(ctx)
 }
 
-func (bt *BlinkyTape) render() error {
+func (bt *BlinkyTape) render(ctx context.Context) error {
 	if bt.buffer.Len() == 0 {
 		return ErrEmptyBuffer
 	}
-	if _, errW := bt.buffer.Write([]byte{ControlHeader}); errW != nil {
-		return ErrWriteCtrlHeader
-	}
-	if err := bt.sendBytes(bt.buffer.Bytes()); err != nil {
+	// the buffer is kept after a failure,
+	// so that it can be rendered again
+	if err := bt.sendBytesContext(ctx, bt.encode(bt.buffer.Bytes())); err != nil {
 		return err
 	}
 	bt.clear()
-	bt.currState = bt.nextState
+
+	bt.stateMutex.Lock()
+	copy(bt.currState, bt.nextState)
+	bt.stateMutex.Unlock()
 
 	return nil
 }
 
 // Reset discards any changes made to the LED strip's state.
 func (bt *BlinkyTape) Reset() error {
-	if bt.IsRunning() {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
 	bt.clear()
-	bt.nextState = bt.currState
+
+	bt.stateMutex.Lock()
+	copy(bt.nextState, bt.currState)
+	bt.stateMutex.Unlock()
 
 	return nil
 }
 
+// CurrentState returns a copy of the pixels last rendered on the
+// LED strip. It is safe to call while an animation is being played.
+func (bt *BlinkyTape) CurrentState() Frame {
+	bt.stateMutex.Lock()
+	defer bt.stateMutex.Unlock()
+	return Frame(bt.currState).copy()
+}
+
+// NextState returns a copy of the pixels that will be
+// displayed on the LED strip after the next render.
+// It is safe to call while an animation is being played.
+func (bt *BlinkyTape) NextState() Frame {
+	bt.stateMutex.Lock()
+	defer bt.stateMutex.Unlock()
+	return Frame(bt.nextState).copy()
+}
+
 func (bt *BlinkyTape) clear() {
 	bt.position = 0
 	bt.buffer.Reset()
@@ -161,10 +278,15 @@ func (bt *BlinkyTape) clear() {
 // SwitchOff switches off the LED strip.
 // This actually set the color to black for all pixels and calls Render().
 func (bt *BlinkyTape) SwitchOff() error {
+	return bt.SwitchOffContext(context.Background())
+}
+
+// SwitchOffContext is like SwitchOff, but calls RenderContext().
+func (bt *BlinkyTape) SwitchOffContext(ctx context.Context) error {
 	if err := bt.SetColor(NewRGBColor(0, 0, 0)); err != nil {
 		return err
 	}
-	return bt.Render()
+	return bt.RenderContext(ctx)
 }
 
 // Play plays an Animation with the LED strip.
@@ -172,29 +294,63 @@ func (bt *BlinkyTape) SwitchOff() error {
 // favor of the new one. The animation loop can be paused, resumed
 // or stopped at any moment, regardless its status.
 // A negative number of repetitions will start an infinite loop.
-func (bt *BlinkyTape) Play(a *Animation, cfg *AnimationConfig) {
+// A configuration without delay uses the one of the animation.
+// The frames of the animation that have their own duration are
+// held for it, regardless the delay of the configuration.
+// If the pattern of the animation is generated from an effect
+// that fails, or is empty, the animation is not played.
+// The returned playback reports when the animation is over.
+func (bt *BlinkyTape) Play(a *Animation, cfg *AnimationConfig) *Playback {
+	return bt.PlayContext(context.Background(), a, cfg)
+}
+
+// PlayContext is like Play, but the animation is stopped
+// when the context is done.
+func (bt *BlinkyTape) PlayContext(ctx context.Context, a *Animation, cfg *AnimationConfig) *Playback {
 	var (
-		repeat int
-		delay  time.Duration
+		repeat     int
+		delay      time.Duration
+		policy     FramePolicy
+		transition *Transition
+		onError    ErrorPolicy
+		retries    uint
 	)
 
 	if cfg == nil {
 		repeat = a.Repeat
-		if a.Speed != 0 {
-			delay = time.Second / time.Duration(a.Speed)
-		} else {
-			delay = AnimationDefaultDelay
-		}
+		delay = a.Delay()
 	} else {
 		repeat = cfg.Repeat
 		delay = cfg.Delay
+		if delay == 0 {
+			delay = a.Delay()
+		}
+		policy = cfg.Policy
+		transition = cfg.Transition
+		onError = cfg.OnError
+		retries = cfg.Retries
 	}
 
+	src, err := a.source(bt.PixelCount)
+	if err != nil {
+		return donePlayback(err)
+	}
+	if _, ok := firstFrame(src); !ok {
+		return donePlayback(nil)
+	}
 	// avoid entering the loop if there is no repetitions to process
-	if repeat != 0 {
-		bt.Stop()
-		go bt.animation(a.Pattern, repeat, delay)
+	if repeat == 0 {
+		return donePlayback(nil)
 	}
+	return bt.play(ctx, loopConfig{
+		source:     src,
+		duration:   a.frameDuration(delay),
+		repeat:     repeat,
+		policy:     policy,
+		transition: transition,
+		onError:    onError,
+		retries:    retries,
+	})
 }
 
 // Status returns the animation status of the LED strip.
@@ -204,36 +360,35 @@ func (bt *BlinkyTape) Status() AnimationStatus {
 	return bt.status
 }
 
-func (bt *BlinkyTape) updateStatus(as AnimationStatus) {
-	bt.mutex.Lock()
-	defer bt.mutex.Unlock()
-	bt.status = as
-}
-
 // IsRunning returns whether or not an animation is running.
 func (bt *BlinkyTape) IsRunning() bool {
 	return bt.Status() == StatusRunning
 }
 
+// busy returns whether the state of the LED strip is being
+// modified by an animation or a stream of frames.
+func (bt *BlinkyTape) busy() bool {
+	status := bt.Status()
+	return status == StatusRunning || status == StatusStreaming
+}
+
 // Stop stops the animation being played on the LED strip. A stop can
 // occur at any moment between the render of two frames regardless the
-// delay, or during a pause.
+// delay, or during a pause. It also stops a stream of frames.
+// It returns once the animation loop is over.
 // If there is no animation being played or paused, do nothing.
 func (bt *BlinkyTape) Stop() {
-	bt.mutex.Lock()
-	defer bt.mutex.Unlock()
-	if bt.status == StatusRunning || bt.status == StatusPaused {
-		bt.stop <- struct{}{}
+	if s, _ := bt.current(); s != nil {
+		s.stop(ErrStopped)
+		<-s.done
 	}
 }
 
 // Pause pauses the animation being played on the LED strip.
 // If there is no animation being played, do nothing.
 func (bt *BlinkyTape) Pause() {
-	bt.mutex.Lock()
-	defer bt.mutex.Unlock()
-	if bt.status == StatusRunning {
-		bt.pause <- struct{}{}
+	if s, status := bt.current(); status == StatusRunning {
+		s.send(s.pause)
 	}
 }
 
@@ -242,78 +397,224 @@ func (bt *BlinkyTape) Pause() {
 // two frames, the remaining of the delay will be respected.
 // If there is no animation to resume, do nothing.
 func (bt *BlinkyTape) Resume() {
-	bt.mutex.Lock()
-	defer bt.mutex.Unlock()
-	if bt.status == StatusPaused {
-		bt.resume <- struct{}{}
+	if s, status := bt.current(); status == StatusPaused {
+		s.send(s.resume)
 	}
 }
 
-func (bt *BlinkyTape) animation(p Pattern, repeat int, delay time.Duration) {
-	bt.updateStatus(StatusRunning)
+// Results of the animation loop.
+const (
+	// loopDone means the animation loop reached its end.
+	loopDone loopResult = iota
+	// loopStopped means the animation loop has been stopped.
+	loopStopped
+	// loopNext means the animation loop has been asked
+	// to skip to the next animation of a playlist.
+	loopNext
+	// loopPrevious means the animation loop has been asked
+	// to go back to the previous animation of a playlist.
+	loopPrevious
+)
+
+// loopResult represents the reason the animation loop returned.
+type loopResult int
+
+// A loopConfig describes how to play the frames of a source.
+type loopConfig struct {
+	source     FrameSource
+	duration   durationFunc
+	repeat     int
+	policy     FramePolicy
+	transition *Transition
+	onError    ErrorPolicy
+	retries    uint
+}
+
+// play starts a new session that plays the frames of
+// a source in a goroutine, and returns its playback.
+func (bt *BlinkyTape) play(ctx context.Context, lc loopConfig) *Playback {
+	sess := bt.start(ctx, StatusRunning)
+	sess.onError = lc.onError
+	sess.retries = lc.retries
 
-	// if the number of repetitions is less than zero, launch
-	// an infinite loop that can be broken by calling Stop()
-	if repeat < 0 {
-		for {
-			if !bt.playPattern(p, delay) {
-				break
-			}
+	go bt.animation(sess, lc)
+	return sess.Playback
+}
+
+func (bt *BlinkyTape) animation(sess *session, lc loopConfig) {
+	defer bt.end(sess)
+
+	s := newScheduler(lc.policy)
+	bt.updateStats(s)
+
+	res := loopDone
+	if lc.transition != nil {
+		if first, ok := firstFrame(lc.source); ok {
+			p, durations := lc.transition.Pattern(bt.CurrentState(), first)
+			res, _ = bt.playSource(sess, p.Source(), sliceDuration(durations), s)
 		}
-	} else {
-		for i := 0; i < repeat; i++ {
-			if !bt.playPattern(p, delay) {
-				break
-			}
+	}
+	if res == loopDone {
+		bt.repeatSource(sess, lc.source, lc.repeat, lc.duration, s)
+	}
+}
+
+// repeatSource plays the frames of a source the given number of times,
+// until the animation loop is stopped. If the number of repetitions is
+// less than zero, the frames are played indefinitely. It returns the
+// result of the loop, and the number of frames played.
+func (bt *BlinkyTape) repeatSource(sess *session, src FrameSource, repeat int, duration durationFunc, s *scheduler) (loopResult, int) {
+	total := 0
+	for i := 0; repeat < 0 || i < repeat; i++ {
+		sess.repeat = i
+		res, n := bt.playSource(sess, src, duration, s)
+		total += n
+		if res != loopDone {
+			return res, total
+		}
+		// avoid spinning indefinitely on a source without frames
+		if n == 0 {
+			break
 		}
 	}
-	bt.updateStatus(StatusStopped)
+	return loopDone, total
 }
 
-func (bt *BlinkyTape) playPattern(p Pattern, delay time.Duration) bool {
-	bt.clear()
+// playSource rewinds a source and plays its frames. It returns
+// the result of the loop, and the number of frames played.
+func (bt *BlinkyTape) playSource(sess *session, src FrameSource, duration durationFunc, s *scheduler) (loopResult, int) {
+	src.Reset()
 
-	for _, frame := range p {
+	i := 0
+	for ; ; i++ {
+		frame, ok := src.Next()
+		if !ok {
+			break
+		}
+		d := duration(i)
+		end := s.next(d)
+
+		if s.late(d, end) {
+			// the deadline of the frame is already
+			// over, drop it to catch up the schedule
+			s.dropped++
+			bt.updateStats(s)
+			continue
+		}
+		bt.clear()
 		bt.setPixels(frame)
 
-		if err := bt.render(); err != nil {
-			// if the frame cannot be rendered, skip it
-			continue
+		if err := bt.renderFrameRetry(sess, s, i); err == nil {
+			s.rendered++
+			bt.emitFrame(sess, i)
+		} else if sess.ctx.Err() == nil && sess.onError != ErrorSkip {
+			sess.stop(err)
+		}
+		bt.updateStats(s)
+		if sess.ctx.Err() != nil {
+			return loopStopped, i + 1
+		}
+
+		if res := bt.waitDeadline(sess, s, end); res != loopDone {
+			return res, i + 1
+		}
+	}
+	return loopDone, i
+}
+
+// renderFrameRetry renders the frame set on the LED strip, and retries
+// as many times as allowed by the error policy of the session if it
+// fails, after a delay that grows with each attempt. The failures are
+// accounted by the scheduler, and reported as events, unless they are
+// caused by the session being stopped.
+func (bt *BlinkyTape) renderFrameRetry(sess *session, s *scheduler, frame int) error {
+	var retries uint
+	if sess.onError == ErrorRetry {
+		retries = sess.retries
+	}
+	backoff := minRetryBackoff
+
+	for n := uint(0); ; n++ {
+		err := bt.render(sess.ctx)
+		if err == nil || sess.ctx.Err() != nil {
+			return err
 		}
-		timer := timer.NewTimer(delay)
-		timer.Start()
+		s.errors++
+		s.lastErr = err
+		bt.emitError(sess, frame, err)
 
+		if n >= retries {
+			return err
+		}
+		timer := time.NewTimer(backoff)
 		select {
-		case <-bt.stop:
-			return false
-		case <-bt.pause:
-			if paused := timer.Pause(); paused != false {
-				bt.updateStatus(StatusPaused)
-				select {
-				case <-bt.stop:
-					return false
-				case <-bt.resume:
-					if started := timer.Start(); started != false {
-						bt.updateStatus(StatusRunning)
-						select {
-						case <-bt.stop:
-							return false
-						case <-timer.C:
-							continue
-						}
-					}
-				}
-			}
+		case <-sess.ctx.Done():
+			timer.Stop()
+			return err
 		case <-timer.C:
-			continue
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// waitDeadline waits until the deadline of a frame, expressed as an offset
+// from the start of the animation. The animation can be paused meanwhile,
+// and the time spent in pause is not accounted. It returns early if the
+// animation is stopped, or skipped in favor of another one.
+func (bt *BlinkyTape) waitDeadline(sess *session, s *scheduler, end time.Duration) loopResult {
+	for {
+		timer := time.NewTimer(s.until(end))
+
+		select {
+		case <-sess.ctx.Done():
+			timer.Stop()
+			return loopStopped
+		case res := <-sess.skip:
+			timer.Stop()
+			return res
+		case <-timer.C:
+			return loopDone
+		case ack := <-sess.pause:
+			timer.Stop()
+			pausedAt := time.Now()
+			bt.updateStatus(sess, StatusPaused)
+			close(ack)
+			bt.emit(sess, EventPaused)
+
+			select {
+			case <-sess.ctx.Done():
+				return loopStopped
+			case ack := <-sess.resume:
+				s.shift(time.Since(pausedAt))
+				bt.updateStatus(sess, StatusRunning)
+				close(ack)
+				bt.emit(sess, EventResumed)
+			}
 		}
 	}
-	return true
+}
+
+// updateStats uses its own mutex, so that updating the statistics
+// at each frame doesn't contend with the controls of the animation.
+func (bt *BlinkyTape) updateStats(s *scheduler) {
+	bt.statsMutex.Lock()
+	defer bt.statsMutex.Unlock()
+	bt.stats = s.stats()
+}
+
+// Stats returns the statistics of the last animation played
+// by the LED strip, or of the one being played.
+func (bt *BlinkyTape) Stats() AnimationStats {
+	bt.statsMutex.Lock()
+	defer bt.statsMutex.Unlock()
+	return bt.stats
 }
 
 // SetColor sets all pixels to the same color.
 func (bt *BlinkyTape) SetColor(c Color) error {
-	if bt.IsRunning() {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
 	bt.clear()
@@ -329,7 +630,7 @@ func (bt *BlinkyTape) SetColor(c Color) error {
 
 // SetPixels sets pixels from a list.
 func (bt *BlinkyTape) SetPixels(p []Pixel) error {
-	if bt.IsRunning() {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
 	return bt.setPixels(p)
@@ -349,7 +650,7 @@ func (bt *BlinkyTape) setPixels(pixels []Pixel) error {
 
 // SetNextPixel sets a pixel at the next position.
 func (bt *BlinkyTape) SetNextPixel(p Pixel) error {
-	if bt.IsRunning() {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
 	return bt.setNextPixel(p)
@@ -362,14 +663,16 @@ func (bt *BlinkyTape) setNextPixel(p Pixel) error {
 			MaxRange: bt.PixelCount - 1,
 		}
 	}
-	if _, err := bt.buffer.Write(p.clampedRGBTriplet()); err != nil {
+	if _, err := bt.buffer.Write(p.rgbTriplet()); err != nil {
 		return PixelError{
 			Pixel:    p,
 			Position: bt.position,
 			err:      err,
 		}
 	}
+	bt.stateMutex.Lock()
 	bt.nextState[bt.position] = p
+	bt.stateMutex.Unlock()
 	bt.position++
 
 	return nil
@@ -378,30 +681,82 @@ func (bt *BlinkyTape) setNextPixel(p Pixel) error {
 // SetPixelAt sets a pixel at the specified position.
 // The operation has to rewrite the whole buffer.
 func (bt *BlinkyTape) SetPixelAt(p *Pixel, position uint) error {
-	if bt.IsRunning() {
+	if bt.busy() {
 		return ErrBusyPlaying
 	}
-	if position > bt.PixelCount {
+	if position >= bt.PixelCount {
 		return ErrOutOfRange
 	}
 
+	bt.stateMutex.Lock()
+	defer bt.stateMutex.Unlock()
+
 	bt.nextState[position] = *p
 	bt.buffer.Reset()
 
 	for _, p := range bt.nextState {
-		if _, err := bt.buffer.Write(p.clampedRGBTriplet()); err != nil {
+		if _, err := bt.buffer.Write(p.rgbTriplet()); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// writeDeadliner is implemented by the transports that
+// support write deadlines, such as net.Conn.
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// sendBytesContext is like sendBytes, but returns ctx.Err() as soon as
+// the context is done. If the transport supports write deadlines, the
+// pending write is aborted, otherwise it completes in the background.
+func (bt *BlinkyTape) sendBytesContext(ctx context.Context, data []byte) error {
+	if ctx.Done() == nil {
+		return bt.sendBytes(data)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// the data is copied since the write may outlive the call
+	data = append([]byte(nil), data...)
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- bt.sendBytes(data)
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		if wd, ok := bt.getTransport().(writeDeadliner); ok {
+			wd.SetWriteDeadline(time.Now())
+			<-errc
+			wd.SetWriteDeadline(time.Time{})
+		}
+		return ctx.Err()
+	}
+}
+
 func (bt *BlinkyTape) sendBytes(data []byte) error {
-	if err := bt.serial.Flush(); err != nil {
+	bt.writeMutex.Lock()
+	defer bt.writeMutex.Unlock()
+
+	if bt.reconnecting {
+		return ErrDisconnected
+	}
+	if err := bt.transport.Flush(); err != nil {
+		bt.failed(err)
 		return err
 	}
-	if _, err := bt.serial.Write(data); err != nil {
+	if _, err := bt.transport.Write(data); err != nil {
+		bt.failed(err)
 		return err
 	}
+	// keep the last state rendered, to restore
+	// it if the transport is reopened
+	if len(data) > 1 {
+		bt.lastRender = append(bt.lastRender[:0], data...)
+	}
 	return nil
 }
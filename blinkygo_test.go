@@ -0,0 +1,95 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestTape returns a BlinkyTape backed by a VirtualTape,
+// without color correction so that the colors are recorded as is.
+func newTestTape(t *testing.T, count uint) (*BlinkyTape, *VirtualTape) {
+	vt := NewVirtualTape(count)
+	bt, err := NewBlinkyTapeWithTransport(vt, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt.SetColorCorrection(nil)
+	return bt, vt
+}
+
+func TestStateDuringPlayback(t *testing.T) {
+	bt, _ := newTestTape(t, 4)
+	defer bt.Close()
+
+	red := Frame{{Color: Color{R: 200}}, {}, {}, {}}
+	blue := Frame{{}, {}, {}, {Color: Color{B: 200}}}
+	anim := &Animation{Pattern: Pattern{red, blue}}
+
+	pb := bt.Play(anim, &AnimationConfig{Repeat: -1, Delay: time.Millisecond})
+
+	// poll the states while the frames are rendered,
+	// until one of the frames shows up at least
+	var rendered bool
+	start := time.Now()
+	for !rendered || time.Since(start) < 50*time.Millisecond {
+		if time.Since(start) > 2*time.Second {
+			t.Fatal("no frame of the animation rendered")
+		}
+		f := bt.CurrentState()
+		if len(f) != 4 {
+			t.Fatalf("current state has %d pixels, want 4", len(f))
+		}
+		if f := bt.NextState(); len(f) != 4 {
+			t.Fatalf("next state has %d pixels, want 4", len(f))
+		}
+		rendered = rendered || reflect.DeepEqual(f, red) || reflect.DeepEqual(f, blue)
+	}
+	bt.Stop()
+	if err := pb.Wait(); err != ErrStopped {
+		t.Errorf("playback ended with %v, want %v", err, ErrStopped)
+	}
+}
+
+func TestPlayDefaultDelay(t *testing.T) {
+	bt, vt := newTestTape(t, 1)
+	defer bt.Close()
+	vt.Reset()
+
+	// a configuration without delay uses the one of the animation
+	anim := &Animation{Speed: 20, Pattern: Pattern{testFrame(1), testFrame(1)}}
+	if err := bt.Play(anim, &AnimationConfig{Repeat: 1}).Wait(); err != nil {
+		t.Fatal(err)
+	}
+	frames := vt.Frames()
+	if len(frames) != 2 {
+		t.Fatalf("%d frames rendered, want 2", len(frames))
+	}
+	if d := frames[1].Time.Sub(frames[0].Time); d < 40*time.Millisecond {
+		t.Errorf("frames rendered %s apart, want about 50ms", d)
+	}
+}
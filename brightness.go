@@ -0,0 +1,203 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "math"
+
+// Default current drawn by the LEDs of a BlinkyTape, in milliamps.
+const (
+	// DefaultChannelCurrent is the current drawn by
+	// a channel of a LED set to its full intensity.
+	DefaultChannelCurrent = 20.0
+	// DefaultIdleCurrent is the current drawn by a LED switched off.
+	DefaultIdleCurrent = 1.0
+)
+
+// A PowerLimit describes the current budget of a LED strip. The current
+// drawn by each frame is estimated from the values sent to the LED strip,
+// and the frames that would exceed the budget are dimmed to fit in.
+type PowerLimit struct {
+	// MaxCurrent is the budget of the LED strip, in milliamps
+	MaxCurrent float64 `json:"maxCurrent"`
+	// ChannelCurrent is the current drawn by a channel of a LED set to
+	// its full intensity, in milliamps, DefaultChannelCurrent if null
+	ChannelCurrent float64 `json:"channelCurrent,omitempty"`
+	// IdleCurrent is the current drawn by a LED switched off,
+	// in milliamps, DefaultIdleCurrent if null
+	IdleCurrent float64 `json:"idleCurrent,omitempty"`
+}
+
+// Current returns the estimation of the current drawn by a LED
+// strip that renders the given RGB triplets, in milliamps.
+func (pl PowerLimit) Current(triplets []byte) float64 {
+	channel, idle := pl.currents()
+
+	var sum float64
+	for _, v := range triplets {
+		sum += float64(v)
+	}
+	return idle*float64(len(triplets)/3) + channel*sum/255
+}
+
+func (pl PowerLimit) currents() (float64, float64) {
+	channel, idle := pl.ChannelCurrent, pl.IdleCurrent
+	if channel == 0 {
+		channel = DefaultChannelCurrent
+	}
+	if idle == 0 {
+		idle = DefaultIdleCurrent
+	}
+	return channel, idle
+}
+
+// factor returns the factor to apply to the RGB triplets
+// so that the current they draw fits in the budget.
+func (pl PowerLimit) factor(triplets []byte) float64 {
+	_, idle := pl.currents()
+	// the idle current cannot be reduced
+	base := idle * float64(len(triplets)/3)
+
+	current := pl.Current(triplets)
+	if current <= pl.MaxCurrent || current <= base {
+		return 1
+	}
+	return math.Max(0, pl.MaxCurrent-base) / (current - base)
+}
+
+// SetBrightness sets the brightness of the LED strip, from 0 (off) to
+// 1 (full), which scales the colors rendered. The pixels of the LED
+// strip's state, and of the animations, keep their original colors.
+// The current state of the LED strip is rendered again with the new
+// brightness, unless an animation is being played.
+func (bt *BlinkyTape) SetBrightness(b float64) error {
+	bt.setBrightness(b)
+	return bt.refresh()
+}
+
+func (bt *BlinkyTape) setBrightness(b float64) {
+	bt.mutex.Lock()
+	bt.brightness = math.Max(0, math.Min(1, b))
+	bt.mutex.Unlock()
+}
+
+// Brightness returns the brightness of the LED strip.
+func (bt *BlinkyTape) Brightness() float64 {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	return bt.brightness
+}
+
+// SetPixelBrightness sets the brightness of each pixel of the LED
+// strip, from 0 (off) to 1 (full), which scales the colors rendered
+// like SetBrightness does. The pixels beyond the list keep their full
+// brightness, and a nil list resets the brightness of all pixels.
+// The current state of the LED strip is rendered again like with
+// SetBrightness.
+func (bt *BlinkyTape) SetPixelBrightness(b []float64) error {
+	bt.setPixelBrightness(b)
+	return bt.refresh()
+}
+
+func (bt *BlinkyTape) setPixelBrightness(b []float64) {
+	var mask []float64
+	if b != nil {
+		mask = make([]float64, len(b))
+		for i, v := range b {
+			mask[i] = math.Max(0, math.Min(1, v))
+		}
+	}
+	bt.mutex.Lock()
+	bt.mask = mask
+	bt.mutex.Unlock()
+}
+
+// SetPowerLimit sets the current budget of the LED strip, or removes
+// it if nil. The frames rendered, including the ones of the animations,
+// are dimmed when needed so that they don't draw more current than the
+// budget, once their colors are corrected and scaled by the brightness.
+// The current state of the LED strip is rendered again like with
+// SetBrightness.
+func (bt *BlinkyTape) SetPowerLimit(pl *PowerLimit) error {
+	bt.setPowerLimit(pl)
+	return bt.refresh()
+}
+
+func (bt *BlinkyTape) setPowerLimit(pl *PowerLimit) {
+	if pl != nil {
+		c := *pl
+		pl = &c
+	}
+	bt.mutex.Lock()
+	bt.limit = pl
+	bt.mutex.Unlock()
+}
+
+// refresh renders the current state of the LED strip again, so that a
+// change of its output is visible without rendering it. Nothing is sent
+// before the first render, nor while an animation is being played, since
+// its next frame is rendered with the change.
+func (bt *BlinkyTape) refresh() error {
+	if bt.busy() {
+		return nil
+	}
+	bt.writeMutex.Lock()
+	rendered := len(bt.lastRender) > 1
+	bt.writeMutex.Unlock()
+
+	if !rendered {
+		return nil
+	}
+	state := bt.CurrentState()
+	triplets := make([]byte, 0, len(state)*3)
+	for _, p := range state {
+		triplets = append(triplets, p.rgbTriplet()...)
+	}
+	return bt.sendBytes(bt.encode(triplets))
+}
+
+// dim scales the RGB triplets, corrected, by the brightness of the
+// LED strip and of each pixel, then by the factor of the power limit.
+func (bt *BlinkyTape) dim(triplets []byte) {
+	bt.mutex.Lock()
+	brightness, mask, limit := bt.brightness, bt.mask, bt.limit
+	bt.mutex.Unlock()
+
+	if brightness != 1 || mask != nil {
+		for i, v := range triplets {
+			f := brightness
+			if i/3 < len(mask) {
+				f *= mask[i/3]
+			}
+			triplets[i] = byte(float64(v) * f)
+		}
+	}
+	if limit != nil {
+		if f := limit.factor(triplets); f < 1 {
+			for i, v := range triplets {
+				triplets[i] = byte(float64(v) * f)
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+// Package client provides a client for the HTTP API exposed by the
+// server package. Its method set mirrors the one of BlinkyTape.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	blinky "github.com/wI2L/blinkygo"
+	"github.com/wI2L/blinkygo/server"
+)
+
+// An Error describes an error replied by the server.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("server error (%d): %s", e.StatusCode, e.Message)
+}
+
+// A Client controls a LED strip shared by a server.
+type Client struct {
+	baseURL string
+
+	// HTTPClient is the client used to send requests.
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// New returns a new Client for the server at the given base URL,
+// for example "http://localhost:8080".
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// SetColor sets all pixels to the same color.
+func (c *Client) SetColor(color blinky.Color) error {
+	return c.do(http.MethodPut, "/color", color, nil)
+}
+
+// SetPixels sets pixels from a list.
+func (c *Client) SetPixels(p []blinky.Pixel) error {
+	return c.do(http.MethodPut, "/pixels", p, nil)
+}
+
+// SetPixelAt sets a pixel at the specified position.
+func (c *Client) SetPixelAt(p *blinky.Pixel, position uint) error {
+	return c.do(http.MethodPut, "/pixel", server.PixelRequest{
+		Pixel:    *p,
+		Position: position,
+	}, nil)
+}
+
+// SetBrightness sets the brightness of the LED strip, from 0 to 1.
+func (c *Client) SetBrightness(b float64) error {
+	return c.do(http.MethodPut, "/brightness", b, nil)
+}
+
+// Render renders the accumulated changes on the LED strip.
+func (c *Client) Render() error {
+	return c.do(http.MethodPost, "/render", nil, nil)
+}
+
+// Reset discards any changes made to the LED strip's state.
+func (c *Client) Reset() error {
+	return c.do(http.MethodPost, "/reset", nil, nil)
+}
+
+// SwitchOff switches off the LED strip.
+func (c *Client) SwitchOff() error {
+	return c.do(http.MethodPost, "/off", nil, nil)
+}
+
+// Play plays an Animation with the LED strip.
+func (c *Client) Play(a *blinky.Animation, cfg *blinky.AnimationConfig) error {
+	req := server.PlayRequest{Animation: *a}
+	if cfg != nil {
+		req.Config = &server.PlayConfig{Repeat: cfg.Repeat}
+		// a null delay is left to the server,
+		// which uses the one of the animation
+		if cfg.Delay != 0 {
+			req.Config.Delay = cfg.Delay.String()
+		}
+	}
+	return c.do(http.MethodPost, "/play", req, nil)
+}
+
+// Pause pauses the animation being played on the LED strip.
+func (c *Client) Pause() error {
+	return c.do(http.MethodPost, "/pause", nil, nil)
+}
+
+// Resume resumes a previous animation that was paused.
+func (c *Client) Resume() error {
+	return c.do(http.MethodPost, "/resume", nil, nil)
+}
+
+// Stop stops the animation being played on the LED strip.
+func (c *Client) Stop() error {
+	return c.do(http.MethodPost, "/stop", nil, nil)
+}
+
+// Status returns the animation status of the LED strip.
+func (c *Client) Status() (blinky.AnimationStatus, error) {
+	resp, err := c.FullStatus()
+	if err != nil {
+		return blinky.StatusStopped, err
+	}
+	return resp.Status, nil
+}
+
+// FullStatus returns the animation status of the LED strip, along
+// with the number of frames of the last animation that failed to
+// render and the error of the last one, so that a dead LED strip
+// can be detected.
+func (c *Client) FullStatus() (*server.StatusResponse, error) {
+	var resp server.StatusResponse
+	if err := c.do(http.MethodGet, "/status", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// IsRunning returns whether or not an animation is running.
+func (c *Client) IsRunning() (bool, error) {
+	status, err := c.Status()
+	return status == blinky.StatusRunning, err
+}
+
+// do sends a request with the given body encoded in JSON, and
+// decodes the response body in out, if not nil. An error replied
+// with the status 409 Conflict is returned as ErrBusyPlaying.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, &body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		if resp.StatusCode == http.StatusConflict {
+			return blinky.ErrBusyPlaying
+		}
+		var e server.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+			e.Error = resp.Status
+		}
+		return Error{
+			StatusCode: resp.StatusCode,
+			Message:    e.Error,
+		}
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
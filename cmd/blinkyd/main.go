@@ -0,0 +1,100 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+// Command blinkyd is a daemon that owns a BlinkyTape LED strip and
+// exposes it over HTTP, so that several processes can share it.
+// See the server package for a description of the endpoints, and
+// the client package for a Go client.
+//
+// Usage:
+//
+//	blinkyd -port /dev/ttyACM0 -pixels 60 -addr localhost:8080 -reconnect 2s
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	blinky "github.com/wI2L/blinkygo"
+	// Procedural effects referenced by animations
+	_ "github.com/wI2L/blinkygo/effects"
+	"github.com/wI2L/blinkygo/server"
+)
+
+var (
+	portName   = flag.String("port", os.Getenv("BLINKYGO_PORT"), "serial port name of the LED strip")
+	pixelCount = flag.Uint("pixels", 60, "number of pixels of the LED strip")
+	addr       = flag.String("addr", "localhost:8080", "address to listen on")
+	maxCurrent = flag.Float64("max-current", 0, "current budget of the LED strip in milliamps, if not null")
+	reconnect  = flag.Duration("reconnect", 0, "reopen the serial port at this interval after a failure, if not null")
+)
+
+func main() {
+	flag.Parse()
+
+	if *portName == "" {
+		log.Fatal("no serial port specified, use -port or $BLINKYGO_PORT")
+	}
+	bt, err := blinky.NewBlinkyTape(*portName, *pixelCount)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer bt.Close()
+
+	if *maxCurrent > 0 {
+		bt.SetPowerLimit(&blinky.PowerLimit{MaxCurrent: *maxCurrent})
+	}
+	if *reconnect > 0 {
+		err := bt.SetReconnect(&blinky.ReconnectConfig{
+			Open:     blinky.SerialOpener(*portName),
+			Interval: *reconnect,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: server.New(bt),
+	}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		<-sig
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Print(err)
+	}
+}
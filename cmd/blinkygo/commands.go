@@ -0,0 +1,262 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// errUsage is returned when a command is called with invalid arguments.
+var errUsage = errors.New("invalid arguments, see blinkygo -h")
+
+// flagSet returns the flag set of a command, whose usage
+// shows the arguments of the command followed by its flags.
+func flagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		usage := name
+		if args != "" {
+			usage += " " + args
+		}
+		fmt.Fprintf(os.Stderr, "Usage: blinkygo %s\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runColor(args []string) error {
+	fs := flagSet("color", "<hex|name>")
+	fs.Parse(args)
+	args = fs.Args()
+
+	if len(args) != 1 {
+		return errUsage
+	}
+	c, err := parseColor(args[0])
+	if err != nil {
+		return err
+	}
+	bt, err := openTape()
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	if err := bt.SetColor(c); err != nil {
+		return err
+	}
+	return bt.Render()
+}
+
+func runOff(args []string) error {
+	fs := flagSet("off", "")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	bt, err := openTape()
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	return bt.SwitchOff()
+}
+
+func runPixel(args []string) error {
+	fs := flagSet("pixel", "<position> <hex|name>")
+	fs.Parse(args)
+	args = fs.Args()
+
+	if len(args) != 2 {
+		return errUsage
+	}
+	pos, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return fmt.Errorf("invalid position %q", args[0])
+	}
+	if pos >= uint64(*pixelCount) {
+		return fmt.Errorf("position %d is out of range [0-%d]", pos, *pixelCount-1)
+	}
+	c, err := parseColor(args[1])
+	if err != nil {
+		return err
+	}
+	bt, err := openTape()
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	if err := bt.SetPixelAt(&blinky.Pixel{Color: c}, uint(pos)); err != nil {
+		return err
+	}
+	return bt.Render()
+}
+
+// animationFlags registers the flags used to
+// override the parameters of an animation.
+func animationFlags(fs *flag.FlagSet) (repeat *int, delay *time.Duration) {
+	repeat = fs.Int("repeat", 0, "number of repetitions, negative to loop forever (default from the animation)")
+	delay = fs.Duration("delay", 0, "delay between two frames (default from the animation)")
+	return
+}
+
+// animationConfig returns the configuration to play an animation
+// with, or nil if none of the animation parameters is overridden.
+func animationConfig(a *blinky.Animation, repeat int, delay time.Duration) *blinky.AnimationConfig {
+	if repeat == 0 && delay == 0 {
+		return nil
+	}
+	cfg := &blinky.AnimationConfig{
+		Repeat: a.Repeat,
+		Delay:  a.Delay(),
+	}
+	if repeat != 0 {
+		cfg.Repeat = repeat
+	}
+	if delay != 0 {
+		cfg.Delay = delay
+	}
+	return cfg
+}
+
+func runPlay(args []string) error {
+	fs := flagSet("play", "[flags] <file>")
+	repeat, delay := animationFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+	anim, err := loadAnimation(fs.Arg(0), *pixelCount)
+	if err != nil {
+		return err
+	}
+	bt, err := openTape()
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	pb := bt.Play(anim, animationConfig(anim, *repeat, *delay))
+
+	return waitPlayback(bt, pb)
+}
+
+func runPreview(args []string) error {
+	fs := flagSet("preview", "[flags] <file>")
+	repeat, delay := animationFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+	anim, err := loadAnimation(fs.Arg(0), *pixelCount)
+	if err != nil {
+		return err
+	}
+	term := blinky.NewTerminal(os.Stdout, *pixelCount)
+	term.Inline = true
+
+	bt, err := blinky.NewBlinkyTapeWithTransport(term, *pixelCount)
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	// a terminal displays the colors as is,
+	// unlike the LEDs of the strip
+	bt.SetColorCorrection(nil)
+
+	pb := bt.Play(anim, animationConfig(anim, *repeat, *delay))
+
+	return waitPlayback(bt, pb)
+}
+
+func runConvert(args []string) error {
+	fs := flagSet("convert", "[flags] <file> <output.json>")
+	name := fs.String("name", "", "name of the animation (default from the file name)")
+	repeat := fs.Int("repeat", 1, "number of repetitions, negative to loop forever")
+	speed := fs.Uint("speed", 0, "playback speed of the animation")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+	anim, err := loadAnimation(fs.Arg(0), *pixelCount)
+	if err != nil {
+		return err
+	}
+	if *name != "" {
+		anim.Name = *name
+	}
+	anim.Repeat = *repeat
+	anim.Speed = *speed
+
+	return anim.SaveToFile(fs.Arg(1))
+}
+
+// waitPlayback blocks until the playback is over, and returns its
+// error, or stops it when an interrupt signal is received.
+func waitPlayback(bt *blinky.BlinkyTape, pb *blinky.Playback) error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+
+	select {
+	case <-sig:
+		bt.Stop()
+		return nil
+	case <-pb.Done():
+		return pb.Err()
+	}
+}
+
+func runList(args []string) error {
+	fs := flagSet("list", "")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	devices, err := blinky.Discover()
+	if err != nil {
+		return err
+	}
+	for _, d := range devices {
+		fmt.Println(d)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+// Command blinkygo controls a BlinkyTape LED strip from the command line.
+//
+// Usage:
+//
+//	blinkygo [flags] <command> [arguments]
+//
+// The commands are:
+//
+//	color <hex|name>               set all pixels to the same color
+//	off                            switch off the LED strip
+//	pixel <position> <hex|name>    set a single pixel
+//	play <file>                    play an animation, an image, a GIF or an Arduino export
+//	convert <file> <output.json>   convert an image or an Arduino export to an animation
+//	preview <file>                 preview an animation in the terminal
+//	list                           list the LED strips plugged in the machine
+//
+// Run "blinkygo <command> -h" for the flags of a command.
+// If no serial port is specified, the first LED strip
+// plugged in the machine is used.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+
+	blinky "github.com/wI2L/blinkygo"
+	// Procedural effects referenced by animations
+	_ "github.com/wI2L/blinkygo/effects"
+)
+
+// Environment variable used as the default serial port name.
+const portEnvVar = "BLINKYGO_PORT"
+
+var (
+	portName   = flag.String("port", os.Getenv(portEnvVar), "serial port name of the LED strip")
+	pixelCount = flag.Uint("pixels", 60, "number of pixels of the LED strip")
+)
+
+// A command is a subcommand of the tool.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"color", "<hex|name>", runColor},
+	{"off", "", runOff},
+	{"pixel", "<position> <hex|name>", runPixel},
+	{"play", "[flags] <file>", runPlay},
+	{"convert", "[flags] <file> <output.json>", runConvert},
+	{"preview", "[flags] <file>", runPreview},
+	{"list", "", runList},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(flag.Args()[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "blinkygo %s: %s\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "blinkygo: unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: blinkygo [flags] <command> [arguments]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// openTape opens the LED strip configured by the global flags,
+// or the first one discovered if no serial port is specified.
+func openTape() (*blinky.BlinkyTape, error) {
+	if *portName == "" {
+		bt, err := blinky.OpenFirst(*pixelCount)
+		if err != nil {
+			return nil, fmt.Errorf("no serial port specified, use -port or $%s: %s", portEnvVar, err)
+		}
+		return bt, nil
+	}
+	return blinky.NewBlinkyTape(*portName, *pixelCount)
+}
+
+// parseColor parses a color from its hex color-string
+// format, or from its name as a fallback.
+func parseColor(s string) (blinky.Color, error) {
+	if c, err := blinky.NewHEXColor(s); err == nil {
+		return c, nil
+	}
+	c, err := blinky.NewNamedColor(strings.ToLower(s))
+	if err != nil {
+		return blinky.Color{}, fmt.Errorf("%q is neither an hexadecimal color nor a known color name", s)
+	}
+	return c, nil
+}
+
+// loadAnimation loads an animation from a file. The type of the
+// file is inferred from its extension: JSON files are animations,
+// C header files are Arduino exports, and others are images.
+// The images of a GIF are played one after the other.
+func loadAnimation(path string, count uint) (*blinky.Animation, error) {
+	var (
+		pattern blinky.Pattern
+		err     error
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return blinky.NewAnimationFromFile(path)
+	case ".h":
+		pattern, err = blinky.NewPatternFromArduinoExport(path)
+	case ".gif":
+		return loadGIF(path, count)
+	default:
+		return blinky.NewAnimationFromImage(path, count)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(pattern) == 0 {
+		return nil, errors.New("pattern has no frames")
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return &blinky.Animation{
+		Name:    name,
+		Repeat:  1,
+		Pattern: pattern,
+	}, nil
+}
+
+// loadGIF loads an animation from the images of a GIF, whose
+// pixels are sampled along the middle row of the images.
+func loadGIF(path string, count uint) (*blinky.Animation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := gif.DecodeConfig(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	return blinky.NewAnimationFromGIF(path, count, blinky.Sampling{
+		Mode:  blinky.SampleRow,
+		Index: cfg.Height / 2,
+	})
+}
@@ -0,0 +1,171 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"fmt"
+	"math"
+)
+
+// NewHSVColor returns a new Color from its HSV representation. The hue
+// is expressed in degrees, and wraps around the color wheel, while the
+// saturation and the value are between 0 and 1.
+func NewHSVColor(h, s, v float64) Color {
+	s, v = unit(s), unit(v)
+	h = hueSector(h)
+	i := math.Floor(h)
+	f := h - i
+	p, q, t := v*(1-s), v*(1-s*f), v*(1-s*(1-f))
+
+	var r, g, b float64
+	switch int(i) {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return NewRGBColor(toByte(r), toByte(g), toByte(b))
+}
+
+// NewHSLColor returns a new Color from its HSL representation. The hue
+// is expressed in degrees, and wraps around the color wheel, while the
+// saturation and the lightness are between 0 and 1.
+func NewHSLColor(h, s, l float64) Color {
+	s, l = unit(s), unit(l)
+
+	// convert to HSV, which has the same hue
+	v := l + s*math.Min(l, 1-l)
+	var sv float64
+	if v != 0 {
+		sv = 2 * (1 - l/v)
+	}
+	return NewHSVColor(h, sv, v)
+}
+
+// NewKelvinColor returns a new Color of the white light
+// emitted by a black body at the given temperature, in
+// kelvin, from warm (1000K) to cool (40000K).
+func NewKelvinColor(kelvin uint) Color {
+	c := kelvinToRGB(kelvin)
+	return NewRGBColor(toByte(c[0]), toByte(c[1]), toByte(c[2]))
+}
+
+// HSV returns the HSV representation of the color. The hue is
+// expressed in degrees, from 0 to 360 excluded, while the
+// saturation and the value are between 0 and 1.
+// The hue of a gray is 0.
+func (c Color) HSV() (h, s, v float64) {
+	r, g, b := c.unitRGB()
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+
+	v = max
+	if max != 0 {
+		s = (max - min) / max
+	}
+	return c.hue(r, g, b, max, min), s, v
+}
+
+// HSL returns the HSL representation of the color. The hue is
+// expressed in degrees, from 0 to 360 excluded, while the
+// saturation and the lightness are between 0 and 1.
+// The hue of a gray is 0.
+func (c Color) HSL() (h, s, l float64) {
+	r, g, b := c.unitRGB()
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+
+	l = (max + min) / 2
+	if max != min {
+		s = (max - min) / (1 - math.Abs(2*l-1))
+	}
+	return c.hue(r, g, b, max, min), s, l
+}
+
+// Hex returns the color in the 6 digits "html" hex
+// color-string format, such as "#ff0066".
+func (c Color) Hex() string {
+	return fmt.Sprintf("#"+HEX6DigitsForm, c.R, c.G, c.B)
+}
+
+// unitRGB returns the components of the color between 0 and 1.
+func (c Color) unitRGB() (float64, float64, float64) {
+	return float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255
+}
+
+// hue returns the hue, in degrees, of a color
+// whose maximal and minimal components are given.
+func (c Color) hue(r, g, b, max, min float64) float64 {
+	d := max - min
+	if d == 0 {
+		return 0
+	}
+	var h float64
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// hueSector returns the sector of the color wheel, from
+// 0 to 6 excluded, of a hue expressed in degrees.
+func hueSector(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	// a tiny negative hue rounds to 360 once wrapped
+	if h >= 360 {
+		h = 0
+	}
+	return h / 60
+}
+
+// unit clamps a value between 0 and 1.
+func unit(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// toByte converts a value between 0 and 1 to a byte.
+func toByte(v float64) byte {
+	return byte(math.Floor(unit(v)*255 + 0.5))
+}
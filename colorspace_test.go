@@ -0,0 +1,99 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "testing"
+
+// testColors returns a sample of the RGB colors.
+func testColors() []Color {
+	var colors []Color
+	for r := 0; r < 256; r += 15 {
+		for g := 0; g < 256; g += 17 {
+			for b := 0; b < 256; b += 51 {
+				colors = append(colors, Color{R: byte(r), G: byte(g), B: byte(b)})
+			}
+		}
+	}
+	return colors
+}
+
+func TestHSVRoundTrip(t *testing.T) {
+	for _, c := range testColors() {
+		h, s, v := c.HSV()
+		if got := NewHSVColor(h, s, v); got != c {
+			t.Errorf("NewHSVColor(%v.HSV()) = %v", c, got)
+		}
+	}
+}
+
+func TestHSLRoundTrip(t *testing.T) {
+	for _, c := range testColors() {
+		h, s, l := c.HSL()
+		if got := NewHSLColor(h, s, l); got != c {
+			t.Errorf("NewHSLColor(%v.HSL()) = %v", c, got)
+		}
+	}
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	for _, c := range testColors() {
+		got, err := NewHEXColor(c.Hex())
+		if err != nil || got != c {
+			t.Errorf("NewHEXColor(%q) = %v, %v", c.Hex(), got, err)
+		}
+	}
+}
+
+func TestNewHSVColorHue(t *testing.T) {
+	red := Color{R: 255}
+
+	tests := []struct {
+		h    float64
+		want Color
+	}{
+		{0, red},
+		{360, red},
+		{-360, red},
+		{-1e-14, red},
+		{120, Color{G: 255}},
+		{-120, Color{B: 255}},
+		{600, Color{B: 255}},
+	}
+	for _, tt := range tests {
+		if got := NewHSVColor(tt.h, 1, 1); got != tt.want {
+			t.Errorf("NewHSVColor(%v, 1, 1) = %v, want %v", tt.h, got, tt.want)
+		}
+	}
+}
+
+func TestNewKelvinColor(t *testing.T) {
+	warm, cool := NewKelvinColor(2700), NewKelvinColor(10000)
+	if warm.R != 255 || warm.B >= warm.G {
+		t.Errorf("NewKelvinColor(2700) = %v, want a warm white", warm)
+	}
+	if cool.B != 255 || cool.R >= cool.B {
+		t.Errorf("NewKelvinColor(10000) = %v, want a cool white", cool)
+	}
+}
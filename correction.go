@@ -0,0 +1,156 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "math"
+
+// A ColorCorrection describes how the colors of the pixels are
+// converted to the values sent to the LED strip. The colors are
+// raised to the power of the gamma exponents, balanced to the
+// white point, then scaled by the calibration factors.
+type ColorCorrection struct {
+	// Gamma holds the exponents of the red, green
+	// and blue channels, 1 if null
+	Gamma [3]float64 `json:"gamma"`
+	// Temperature is the color temperature of the white
+	// point, in kelvin, no balance is applied if null
+	Temperature uint `json:"temperature,omitempty"`
+	// Calibration holds the factors of the red, green
+	// and blue channels, 1 if null
+	Calibration [3]float64 `json:"calibration"`
+}
+
+// DefaultColorCorrection returns the color correction of a
+// new BlinkyTape instance, a rough brightness correction that
+// uses RedExponent, GreenExponent and BlueExponent as gamma.
+func DefaultColorCorrection() *ColorCorrection {
+	return &ColorCorrection{
+		Gamma: [3]float64{RedExponent, GreenExponent, BlueExponent},
+	}
+}
+
+// colorLUT is a lookup table that holds the value sent
+// to the LED strip for each value of each channel.
+type colorLUT [3][256]byte
+
+// lut computes the lookup table of the color correction.
+func (cc *ColorCorrection) lut() *colorLUT {
+	var (
+		lut     colorLUT
+		balance = [3]float64{1, 1, 1}
+	)
+	if cc.Temperature != 0 {
+		balance = kelvinToRGB(cc.Temperature)
+	}
+	for c := range lut {
+		gamma := cc.Gamma[c]
+		if gamma == 0 {
+			gamma = 1
+		}
+		factor := cc.Calibration[c]
+		if factor == 0 {
+			factor = 1
+		}
+		for v := range lut[c] {
+			x := math.Pow(float64(v)/255.0, gamma) * balance[c] * factor
+			lut[c][v] = byte(255 * math.Max(0, math.Min(1, x)))
+		}
+	}
+	return &lut
+}
+
+// kelvinToRGB returns the red, green and blue components, in
+// the range 0-1, of the color of a black body at the given
+// temperature, using the approximation of Tanner Helland.
+func kelvinToRGB(kelvin uint) [3]float64 {
+	t := float64(kelvin) / 100
+	var r, g, b float64
+
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+	f := func(v float64) float64 {
+		return math.Max(0, math.Min(255, v)) / 255
+	}
+	return [3]float64{f(r), f(g), f(b)}
+}
+
+// SetColorCorrection sets the color correction applied to the pixels
+// when they are rendered on the LED strip, or disables it if nil, in
+// which case the colors are sent as is. The pixels of the LED strip's
+// state, and of the animations, always keep their original colors.
+// The current state of the LED strip is rendered again like with
+// SetBrightness.
+func (bt *BlinkyTape) SetColorCorrection(cc *ColorCorrection) error {
+	bt.setColorCorrection(cc)
+	return bt.refresh()
+}
+
+func (bt *BlinkyTape) setColorCorrection(cc *ColorCorrection) {
+	var lut *colorLUT
+	if cc != nil {
+		lut = cc.lut()
+	}
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	bt.lut = lut
+}
+
+// encode returns the data to send to the LED strip to render the
+// given RGB triplets, corrected and dimmed, then clamped to 0-254 to
+// avoid confusion with the control header, followed by the header.
+func (bt *BlinkyTape) encode(triplets []byte) []byte {
+	bt.mutex.Lock()
+	lut := bt.lut
+	bt.mutex.Unlock()
+
+	data := make([]byte, len(triplets)+1)
+	for i, v := range triplets {
+		if lut != nil {
+			v = lut[i%3][v]
+		}
+		data[i] = v
+	}
+	bt.dim(data[:len(triplets)])
+
+	for i, v := range data[:len(triplets)] {
+		data[i] = clamp(v)
+	}
+	data[len(triplets)] = ControlHeader
+
+	return data
+}
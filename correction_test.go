@@ -0,0 +1,56 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "testing"
+
+func TestSetColorCorrectionRefresh(t *testing.T) {
+	bt, vt := newTestTape(t, 1)
+	defer bt.Close()
+
+	c := NewRGBColor(100, 100, 100)
+	if err := bt.SetColor(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := bt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if got := vt.Pixels()[0].Color; got != c {
+		t.Fatalf("pixel = %v, want %v", got, c)
+	}
+	// the current state is rendered again with the new correction
+	cc := &ColorCorrection{Gamma: [3]float64{2, 2, 2}}
+	if err := bt.SetColorCorrection(cc); err != nil {
+		t.Fatal(err)
+	}
+	want := Color{R: 39, G: 39, B: 39}
+	if got := vt.Pixels()[0].Color; got != want {
+		t.Errorf("pixel = %v, want %v", got, want)
+	}
+	// the state of the LED strip keeps its original colors
+	if got := bt.CurrentState()[0].Color; got != c {
+		t.Errorf("current state = %v, want %v", got, c)
+	}
+}
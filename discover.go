@@ -0,0 +1,126 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "fmt"
+
+// USB identifiers of the BlinkyTape LED strips.
+const (
+	// BlinkyTapeVendorID is the USB vendor ID of a BlinkyTape.
+	BlinkyTapeVendorID uint16 = 0x1d50
+	// BlinkyTapeProductID is the USB product ID of a BlinkyTape.
+	BlinkyTapeProductID uint16 = 0x605e
+)
+
+// A Device describes a BlinkyTape LED strip plugged in the machine.
+type Device struct {
+	// Port is the name of the serial port of the LED strip
+	Port string `json:"port"`
+	// Serial is the USB serial number of the LED strip
+	Serial string `json:"serial"`
+	// Product and Manufacturer are the USB
+	// product and manufacturer names
+	Product      string `json:"product"`
+	Manufacturer string `json:"manufacturer"`
+	// VendorID and ProductID are the USB identifiers
+	VendorID  uint16 `json:"vendorId"`
+	ProductID uint16 `json:"productId"`
+}
+
+// String implements the fmt.Stringer interface.
+func (d Device) String() string {
+	return fmt.Sprintf("%s (%04x:%04x %s, serial %q)", d.Port, d.VendorID, d.ProductID, d.Product, d.Serial)
+}
+
+// Open creates a new BlinkyTape instance that communicates
+// with the LED strip through the serial port of the device.
+func (d Device) Open(count uint) (*BlinkyTape, error) {
+	return NewBlinkyTape(d.Port, count)
+}
+
+// A Discoverer looks up the BlinkyTape LED strips plugged in the
+// machine. Its roots can point to a fake tree of devices.
+type Discoverer struct {
+	// SysfsRoot is the mount point of sysfs, "/sys" if empty
+	SysfsRoot string
+	// DevRoot is the directory of the device files, "/dev" if empty
+	DevRoot string
+}
+
+// Discover returns the BlinkyTape LED strips plugged in the
+// machine, sorted by port name. It is only supported on Linux.
+func Discover() ([]Device, error) {
+	return Discoverer{}.Discover()
+}
+
+// OpenFirst opens the first BlinkyTape LED strip returned by Discover.
+func OpenFirst(count uint) (*BlinkyTape, error) {
+	devices, err := Discover()
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, ErrNoDevice
+	}
+	return devices[0].Open(count)
+}
+
+// OpenBySerial opens the BlinkyTape LED strip
+// with the given USB serial number.
+func OpenBySerial(serial string, count uint) (*BlinkyTape, error) {
+	d, err := FindBySerial(serial)
+	if err != nil {
+		return nil, err
+	}
+	return d.Open(count)
+}
+
+// FindBySerial returns the BlinkyTape LED strip
+// with the given USB serial number.
+func FindBySerial(serial string) (Device, error) {
+	devices, err := Discover()
+	if err != nil {
+		return Device{}, err
+	}
+	for _, d := range devices {
+		if d.Serial == serial {
+			return d, nil
+		}
+	}
+	return Device{}, ErrNoDevice
+}
+
+// SerialNumberOpener returns an OpenFunc that opens the BlinkyTape
+// LED strip with the given USB serial number, whose port name may
+// have changed since it was unplugged.
+func SerialNumberOpener(serial string) OpenFunc {
+	return func() (Transport, error) {
+		d, err := FindBySerial(serial)
+		if err != nil {
+			return nil, err
+		}
+		return NewSerialTransport(d.Port)
+	}
+}
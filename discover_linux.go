@@ -0,0 +1,111 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Discover returns the BlinkyTape LED strips found in the tree of
+// devices, sorted by port name. The serial ports are listed from the
+// tty class of sysfs, and the attributes of the USB device they belong
+// to are read from the parent directory of their USB interface.
+func (d Discoverer) Discover() ([]Device, error) {
+	sysfs, dev := d.SysfsRoot, d.DevRoot
+	if sysfs == "" {
+		sysfs = "/sys"
+	}
+	if dev == "" {
+		dev = "/dev"
+	}
+	ttys, err := ioutil.ReadDir(filepath.Join(sysfs, "class", "tty"))
+	if err != nil {
+		return nil, err
+	}
+	var devices []Device
+
+	for _, tty := range ttys {
+		// the interface of the tty is missing for virtual terminals
+		iface, err := filepath.EvalSymlinks(filepath.Join(sysfs, "class", "tty", tty.Name(), "device"))
+		if err != nil {
+			continue
+		}
+		usb := filepath.Dir(iface)
+
+		vid, errV := readHexAttr(usb, "idVendor")
+		pid, errP := readHexAttr(usb, "idProduct")
+		if errV != nil || errP != nil {
+			continue
+		}
+		if vid != BlinkyTapeVendorID || pid != BlinkyTapeProductID {
+			continue
+		}
+		port := filepath.Join(dev, tty.Name())
+		if _, err := os.Stat(port); err != nil {
+			continue
+		}
+		devices = append(devices, Device{
+			Port:         port,
+			Serial:       readAttr(usb, "serial"),
+			Product:      readAttr(usb, "product"),
+			Manufacturer: readAttr(usb, "manufacturer"),
+			VendorID:     vid,
+			ProductID:    pid,
+		})
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Port < devices[j].Port
+	})
+	return devices, nil
+}
+
+// readAttr returns the value of an attribute of a sysfs
+// directory, or an empty string if it cannot be read.
+func readAttr(dir, name string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// readHexAttr returns the value of an hexadecimal
+// attribute of a sysfs directory.
+func readHexAttr(dir, name string) (uint16, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(b)), 16, 16)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
@@ -0,0 +1,102 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fakeTTY adds a serial port to a fake tree of devices, whose USB device
+// has the given identifiers. The layout follows the one of sysfs, where
+// the device of a tty links to the interface of its USB device.
+func fakeTTY(t *testing.T, sysfs, dev, name, usb, vid, pid, serial string) {
+	iface := filepath.Join(sysfs, "devices", usb, usb+":1.0")
+	if err := os.MkdirAll(iface, 0755); err != nil {
+		t.Fatal(err)
+	}
+	attrs := map[string]string{
+		"idVendor":     vid,
+		"idProduct":    pid,
+		"serial":       serial,
+		"product":      "BlinkyTape",
+		"manufacturer": "Blinkinlabs",
+	}
+	for attr, value := range attrs {
+		path := filepath.Join(sysfs, "devices", usb, attr)
+		if err := ioutil.WriteFile(path, []byte(value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tty := filepath.Join(sysfs, "class", "tty", name)
+	if err := os.MkdirAll(tty, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(iface, filepath.Join(tty, "device")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dev, name), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root, err := ioutil.TempDir("", "blinkygo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	sysfs, dev := filepath.Join(root, "sys"), filepath.Join(root, "dev")
+	if err := os.MkdirAll(dev, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fakeTTY(t, sysfs, dev, "ttyACM0", "1-1", "1d50", "605e", "5D6F3A1B")
+	fakeTTY(t, sysfs, dev, "ttyACM1", "1-2", "2341", "0043", "ARDUINO")
+
+	// a virtual terminal has no device
+	if err := os.MkdirAll(filepath.Join(sysfs, "class", "tty", "tty0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	devices, err := Discoverer{SysfsRoot: sysfs, DevRoot: dev}.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Device{{
+		Port:         filepath.Join(dev, "ttyACM0"),
+		Serial:       "5D6F3A1B",
+		Product:      "BlinkyTape",
+		Manufacturer: "Blinkinlabs",
+		VendorID:     BlinkyTapeVendorID,
+		ProductID:    BlinkyTapeProductID,
+	}}
+	if !reflect.DeepEqual(devices, want) {
+		t.Errorf("Discover() = %v, want %v", devices, want)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// A GeneratorFunc generates the pattern of an effect for the given
+// number of pixels, from the JSON encoded parameters of the effect.
+type GeneratorFunc func(params json.RawMessage, pixelCount uint) (Pattern, error)
+
+// A SourceFunc returns a source that computes the frames of an effect
+// on the fly for the given number of pixels, from the JSON encoded
+// parameters of the effect.
+type SourceFunc func(params json.RawMessage, pixelCount uint) (FrameSource, error)
+
+var (
+	generators      = make(map[string]GeneratorFunc)
+	sources         = make(map[string]SourceFunc)
+	generatorsMutex sync.RWMutex
+)
+
+// RegisterEffect registers the generator of an effect under the given
+// name, so that it can be referenced by an EffectConfig. Packages that
+// provide effects, such as the effects sub-package, typically call it
+// from their init function, and are imported for their side-effects.
+func RegisterEffect(name string, fn GeneratorFunc) {
+	generatorsMutex.Lock()
+	defer generatorsMutex.Unlock()
+	generators[name] = fn
+}
+
+// RegisterEffectSource registers a function that returns a source
+// of the frames of an effect under the given name, like RegisterEffect
+// does for a generator. The frames of an animation that references
+// the effect are then computed on the fly when it is played.
+func RegisterEffectSource(name string, fn SourceFunc) {
+	generatorsMutex.Lock()
+	defer generatorsMutex.Unlock()
+	sources[name] = fn
+}
+
+// An EffectConfig references a registered effect by its
+// name, along with its parameters encoded in JSON.
+type EffectConfig struct {
+	Name   string          `json:"name"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Generate generates the pattern of the effect
+// for the given number of pixels.
+func (ec EffectConfig) Generate(pixelCount uint) (Pattern, error) {
+	generatorsMutex.RLock()
+	gen, ok := generators[ec.Name]
+	fn := sources[ec.Name]
+	generatorsMutex.RUnlock()
+
+	if ok {
+		return gen(ec.Params, pixelCount)
+	}
+	if fn == nil {
+		return nil, ErrUnknownEffect
+	}
+	src, err := fn(ec.Params, pixelCount)
+	if err != nil {
+		return nil, err
+	}
+	var pattern Pattern
+	for {
+		f, ok := src.Next()
+		if !ok {
+			return pattern, nil
+		}
+		pattern = append(pattern, f)
+	}
+}
+
+// Source returns a source of the frames of the effect for the given
+// number of pixels. If the effect is only registered with a generator,
+// its pattern is generated at once.
+func (ec EffectConfig) Source(pixelCount uint) (FrameSource, error) {
+	generatorsMutex.RLock()
+	fn, ok := sources[ec.Name]
+	generatorsMutex.RUnlock()
+
+	if ok {
+		return fn(ec.Params, pixelCount)
+	}
+	pattern, err := ec.Generate(pixelCount)
+	if err != nil {
+		return nil, err
+	}
+	return pattern.Source(), nil
+}
@@ -0,0 +1,269 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package effects
+
+import (
+	"math/rand"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// ColorWipe lights the pixels of the LED strip
+// one after the other, from its beginning.
+type ColorWipe struct {
+	Color      blinky.Color `json:"color"`
+	Background blinky.Color `json:"background"`
+}
+
+// Name implements the Effect interface.
+func (cw ColorWipe) Name() string { return "color-wipe" }
+
+// Len implements the Effect interface.
+func (cw ColorWipe) Len(pixelCount uint) int {
+	return int(pixelCount)
+}
+
+// Generator implements the Effect interface.
+func (cw ColorWipe) Generator(pixelCount uint) Generator {
+	var k int
+
+	return func() blinky.Frame {
+		f := fill(pixelCount, cw.Background)
+		for i := 0; i <= k; i++ {
+			f[i].Color = cw.Color
+		}
+		k = (k + 1) % int(pixelCount)
+		return f
+	}
+}
+
+// TheaterChase lights every few pixels, and moves
+// them along the LED strip like theater marquee lights.
+type TheaterChase struct {
+	Color      blinky.Color `json:"color"`
+	Background blinky.Color `json:"background"`
+	// Spacing is the distance between two lit pixels. Defaults to 3.
+	Spacing int `json:"spacing,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (tc TheaterChase) Name() string { return "theater-chase" }
+
+// Validate implements the Validator interface.
+func (tc TheaterChase) Validate() error {
+	return nonNegative(tc, param{"spacing", tc.Spacing})
+}
+
+// Len implements the Effect interface.
+func (tc TheaterChase) Len(pixelCount uint) int {
+	return orDefault(tc.Spacing, 3)
+}
+
+// Generator implements the Effect interface.
+func (tc TheaterChase) Generator(pixelCount uint) Generator {
+	var (
+		spacing = tc.Len(pixelCount)
+		k       int
+	)
+	return func() blinky.Frame {
+		f := fill(pixelCount, tc.Background)
+		for i := k; i < len(f); i += spacing {
+			f[i].Color = tc.Color
+		}
+		k = (k + 1) % spacing
+		return f
+	}
+}
+
+// Scanner moves an eye back and forth along the LED strip,
+// followed by a fading tail, like the Cylon or Larson scanners.
+type Scanner struct {
+	Color blinky.Color `json:"color"`
+	// Size is the number of pixels of the eye. Defaults to 1.
+	Size int `json:"size,omitempty"`
+	// Tail is the number of pixels of the tail. Defaults to 4.
+	Tail int `json:"tail,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (sc Scanner) Name() string { return "scanner" }
+
+// Validate implements the Validator interface.
+func (sc Scanner) Validate() error {
+	return nonNegative(sc, param{"size", sc.Size}, param{"tail", sc.Tail})
+}
+
+// Len implements the Effect interface.
+func (sc Scanner) Len(pixelCount uint) int {
+	if pixelCount < 2 {
+		return 1
+	}
+	return 2 * (int(pixelCount) - 1)
+}
+
+// Generator implements the Effect interface.
+func (sc Scanner) Generator(pixelCount uint) Generator {
+	var (
+		size   = orDefault(sc.Size, 1)
+		tail   = orDefault(sc.Tail, 4)
+		frames = sc.Len(pixelCount)
+		k      int
+	)
+	return func() blinky.Frame {
+		// the eye goes forward during the first
+		// half of the cycle, and backward after
+		pos, dir := k, 1
+		if pixelCount > 1 && k >= int(pixelCount)-1 {
+			pos, dir = frames-k, -1
+		}
+		f := make(blinky.Frame, pixelCount)
+		for i := range f {
+			// distance from the eye, towards its tail
+			d := (pos - i) * dir
+			switch {
+			case d >= 0 && d < size:
+				f[i].Color = sc.Color
+			case d >= size && d < size+tail:
+				f[i].Color = sc.Color.Scale(1 - float64(d-size+1)/float64(tail+1))
+			}
+		}
+		k = (k + 1) % frames
+		return f
+	}
+}
+
+// Comet moves a light along the LED strip,
+// followed by a tail that fades progressively.
+type Comet struct {
+	Color blinky.Color `json:"color"`
+	// Tail is the number of pixels of the tail.
+	// Defaults to a quarter of the pixels.
+	Tail int `json:"tail,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (c Comet) Name() string { return "comet" }
+
+// Validate implements the Validator interface.
+func (c Comet) Validate() error {
+	return nonNegative(c, param{"tail", c.Tail})
+}
+
+func (c Comet) tail(pixelCount uint) int {
+	if c.Tail != 0 {
+		return c.Tail
+	}
+	if pixelCount < 4 {
+		return 1
+	}
+	return int(pixelCount) / 4
+}
+
+// Len implements the Effect interface. The cycle lasts
+// until the tail of the comet leaves the LED strip.
+func (c Comet) Len(pixelCount uint) int {
+	return int(pixelCount) + c.tail(pixelCount)
+}
+
+// Generator implements the Effect interface.
+func (c Comet) Generator(pixelCount uint) Generator {
+	var (
+		tail   = c.tail(pixelCount)
+		frames = c.Len(pixelCount)
+		k      int
+	)
+	return func() blinky.Frame {
+		f := make(blinky.Frame, pixelCount)
+		for i := range f {
+			switch d := k - i; {
+			case d == 0:
+				f[i].Color = c.Color
+			case d > 0 && d <= tail:
+				f[i].Color = c.Color.Scale(1 - float64(d)/float64(tail+1))
+			}
+		}
+		k = (k + 1) % frames
+		return f
+	}
+}
+
+// MeteorRain moves a meteor along the LED strip, leaving
+// behind a trail of debris that decays at random.
+type MeteorRain struct {
+	Color blinky.Color `json:"color"`
+	// Size is the number of pixels of the meteor. Defaults to 5.
+	Size int `json:"size,omitempty"`
+	// Decay is the part of its brightness a pixel of the
+	// trail keeps at each frame, between 0 and 1. Defaults to 0.75.
+	Decay float64 `json:"decay,omitempty"`
+	// RandomDecay indicates whether the pixels of the
+	// trail decay at random, rather than at each frame.
+	RandomDecay bool `json:"randomDecay,omitempty"`
+	// Seed initializes the random generator.
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (mr MeteorRain) Name() string { return "meteor-rain" }
+
+// Validate implements the Validator interface.
+func (mr MeteorRain) Validate() error {
+	return nonNegative(mr, param{"size", mr.Size})
+}
+
+// Len implements the Effect interface. The cycle lasts
+// until the trail of the meteor has mostly decayed.
+func (mr MeteorRain) Len(pixelCount uint) int {
+	return 2 * int(pixelCount)
+}
+
+// Generator implements the Effect interface.
+func (mr MeteorRain) Generator(pixelCount uint) Generator {
+	var (
+		size   = orDefault(mr.Size, 5)
+		decay  = orDefaultFloat(mr.Decay, 0.75)
+		frames = mr.Len(pixelCount)
+		rng    = rand.New(rand.NewSource(mr.Seed))
+		f      = make(blinky.Frame, pixelCount)
+		k      int
+	)
+	return func() blinky.Frame {
+		for i := range f {
+			if !mr.RandomDecay || rng.Intn(2) == 0 {
+				f[i].Color = f[i].Color.Scale(decay)
+			}
+		}
+		for j := 0; j < size; j++ {
+			if i := k - j; i >= 0 && i < len(f) {
+				f[i].Color = mr.Color
+			}
+		}
+		k = (k + 1) % frames
+		if k == 0 {
+			f = make(blinky.Frame, pixelCount)
+		}
+		return append(blinky.Frame(nil), f...)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package effects
+
+import (
+	"math"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// Rainbow spreads the hues of the color wheel along
+// the LED strip, and rotates them over time.
+type Rainbow struct {
+	// Cycles is the number of times the color wheel
+	// is repeated along the LED strip. Defaults to 1.
+	Cycles float64 `json:"cycles,omitempty"`
+	// Frames is the number of frames of a full
+	// rotation of the hues. Defaults to 256.
+	Frames int `json:"frames,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (r Rainbow) Name() string { return "rainbow" }
+
+// Validate implements the Validator interface.
+func (r Rainbow) Validate() error {
+	return nonNegative(r, param{"frames", r.Frames})
+}
+
+// Len implements the Effect interface.
+func (r Rainbow) Len(pixelCount uint) int {
+	return orDefault(r.Frames, 256)
+}
+
+// Generator implements the Effect interface.
+func (r Rainbow) Generator(pixelCount uint) Generator {
+	var (
+		frames = r.Len(pixelCount)
+		cycles = orDefaultFloat(r.Cycles, 1)
+		k      int
+	)
+	return func() blinky.Frame {
+		f := make(blinky.Frame, pixelCount)
+		for i := range f {
+			h := float64(i)*cycles/float64(pixelCount) + float64(k)/float64(frames)
+			f[i].Color = blinky.NewHSVColor(h*360, 1, 1)
+		}
+		k = (k + 1) % frames
+		return f
+	}
+}
+
+// PaletteCycle spreads the colors of a palette along
+// the LED strip, and rotates them over time.
+type PaletteCycle struct {
+	// Palette is the palette, or the name of a
+	// registered palette in JSON, such as "lava".
+	Palette blinky.Palette `json:"palette"`
+	// Cycles is the number of times the palette
+	// is repeated along the LED strip. Defaults to 1.
+	Cycles float64 `json:"cycles,omitempty"`
+	// Frames is the number of frames of a full
+	// rotation of the palette. Defaults to 256.
+	Frames int `json:"frames,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (pc PaletteCycle) Name() string { return "palette-cycle" }
+
+// Validate implements the Validator interface.
+func (pc PaletteCycle) Validate() error {
+	return nonNegative(pc, param{"frames", pc.Frames})
+}
+
+// Len implements the Effect interface.
+func (pc PaletteCycle) Len(pixelCount uint) int {
+	return orDefault(pc.Frames, 256)
+}
+
+// Generator implements the Effect interface.
+func (pc PaletteCycle) Generator(pixelCount uint) Generator {
+	var (
+		frames  = pc.Len(pixelCount)
+		cycles  = orDefaultFloat(pc.Cycles, 1)
+		palette = pc.Palette.Sampled()
+		k       int
+	)
+	return func() blinky.Frame {
+		f := make(blinky.Frame, pixelCount)
+		for i := range f {
+			t := float64(i)*cycles/float64(pixelCount) + float64(k)/float64(frames)
+			f[i].Color = palette.At(t)
+		}
+		k = (k + 1) % frames
+		return f
+	}
+}
+
+// Breathing fades the LED strip in and out, like the
+// breathing light of a computer in standby mode.
+type Breathing struct {
+	Color blinky.Color `json:"color"`
+	// Frames is the number of frames of a breath. Defaults to 100.
+	Frames int `json:"frames,omitempty"`
+	// Min is the minimal brightness, between 0 and 1.
+	Min float64 `json:"min,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (b Breathing) Name() string { return "breathing" }
+
+// Validate implements the Validator interface.
+func (b Breathing) Validate() error {
+	return nonNegative(b, param{"frames", b.Frames})
+}
+
+// Len implements the Effect interface.
+func (b Breathing) Len(pixelCount uint) int {
+	return orDefault(b.Frames, 100)
+}
+
+// Generator implements the Effect interface.
+func (b Breathing) Generator(pixelCount uint) Generator {
+	var (
+		frames = b.Len(pixelCount)
+		k      int
+	)
+	return func() blinky.Frame {
+		level := (1 - math.Cos(2*math.Pi*float64(k)/float64(frames))) / 2
+		k = (k + 1) % frames
+		return fill(pixelCount, b.Color.Scale(b.Min+(1-b.Min)*level))
+	}
+}
+
+// ColorCycle fades the whole LED strip from
+// one color to the next, in a loop.
+type ColorCycle struct {
+	Colors []blinky.Color `json:"colors"`
+	// Steps is the number of frames to fade
+	// between two colors. Defaults to 50.
+	Steps int `json:"steps,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (cc ColorCycle) Name() string { return "color-cycle" }
+
+// Validate implements the Validator interface.
+func (cc ColorCycle) Validate() error {
+	return nonNegative(cc, param{"steps", cc.Steps})
+}
+
+// Len implements the Effect interface.
+func (cc ColorCycle) Len(pixelCount uint) int {
+	return len(cc.Colors) * orDefault(cc.Steps, 50)
+}
+
+// Generator implements the Effect interface.
+func (cc ColorCycle) Generator(pixelCount uint) Generator {
+	var (
+		steps = orDefault(cc.Steps, 50)
+		k     int
+	)
+	return func() blinky.Frame {
+		if len(cc.Colors) == 0 {
+			return make(blinky.Frame, pixelCount)
+		}
+		from := cc.Colors[k/steps]
+		to := cc.Colors[(k/steps+1)%len(cc.Colors)]
+		t := float64(k%steps) / float64(steps)
+
+		k = (k + 1) % cc.Len(pixelCount)
+		return fill(pixelCount, from.Lerp(to, t))
+	}
+}
@@ -0,0 +1,221 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+// Package effects provides procedural effects that generate the
+// pattern of an animation for a given number of pixels.
+//
+// Importing this package registers its effects with
+// RegisterEffectSource, so that they can be referenced by the effect
+// of an animation, and played from an animation file. Their frames
+// are then computed on the fly while the animation is played:
+//
+//	import _ "github.com/wI2L/blinkygo/effects"
+//
+// The parameters of the effects are optional, and their zero
+// values are replaced by sensible defaults.
+package effects
+
+import (
+	"encoding/json"
+	"fmt"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// An Effect is a procedural effect.
+type Effect interface {
+	// Name returns the name the effect is registered with.
+	Name() string
+	// Len returns the number of frames of a cycle of the
+	// effect for the given number of pixels.
+	Len(pixelCount uint) int
+	// Generator returns a new generator of the frames
+	// of the effect for the given number of pixels.
+	Generator(pixelCount uint) Generator
+}
+
+// A Validator is an effect that validates its parameters.
+type Validator interface {
+	// Validate returns an error if the parameters
+	// of the effect cannot be used to generate it.
+	Validate() error
+}
+
+// A Generator returns the frames of an effect one after the other.
+// Once a cycle of the effect is complete, it starts over.
+type Generator func() blinky.Frame
+
+// effects maps the name of the effects to their constructors.
+var effects = map[string]func() Effect{
+	"rainbow":       func() Effect { return &Rainbow{} },
+	"color-wipe":    func() Effect { return &ColorWipe{} },
+	"theater-chase": func() Effect { return &TheaterChase{} },
+	"scanner":       func() Effect { return &Scanner{} },
+	"comet":         func() Effect { return &Comet{} },
+	"twinkle":       func() Effect { return &Twinkle{} },
+	"fire":          func() Effect { return &Fire{} },
+	"breathing":     func() Effect { return &Breathing{} },
+	"meteor-rain":   func() Effect { return &MeteorRain{} },
+	"color-cycle":   func() Effect { return &ColorCycle{} },
+	"palette-cycle": func() Effect { return &PaletteCycle{} },
+}
+
+func init() {
+	for name, fn := range effects {
+		blinky.RegisterEffectSource(name, sourceFunc(fn))
+	}
+}
+
+// sourceFunc returns a function that returns a source of
+// the frames of an effect from its JSON encoded parameters.
+func sourceFunc(newEffect func() Effect) blinky.SourceFunc {
+	return func(params json.RawMessage, pixelCount uint) (blinky.FrameSource, error) {
+		e := newEffect()
+		if len(params) != 0 {
+			if err := json.Unmarshal(params, e); err != nil {
+				return nil, err
+			}
+		}
+		if v, ok := e.(Validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, err
+			}
+		}
+		return NewSource(e, pixelCount), nil
+	}
+}
+
+// Pattern returns the frames of a cycle of an effect for the given
+// number of pixels. Use NewSource to compute them on the fly instead.
+// The parameters of the effect must be valid, see Validator.
+func Pattern(e Effect, pixelCount uint) blinky.Pattern {
+	if pixelCount == 0 {
+		return nil
+	}
+	next := e.Generator(pixelCount)
+	pattern := make(blinky.Pattern, e.Len(pixelCount))
+
+	for i := range pattern {
+		pattern[i] = next()
+	}
+	return pattern
+}
+
+// Config returns the configuration of an effect,
+// to use as the effect of an animation.
+func Config(e Effect) (*blinky.EffectConfig, error) {
+	params, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &blinky.EffectConfig{
+		Name:   e.Name(),
+		Params: params,
+	}, nil
+}
+
+// fill returns a frame with all its pixels set to the same color.
+func fill(n uint, c blinky.Color) blinky.Frame {
+	f := make(blinky.Frame, n)
+	for i := range f {
+		f[i].Color = c
+	}
+	return f
+}
+
+// A param is an integer parameter of an effect.
+type param struct {
+	name  string
+	value int
+}
+
+// nonNegative returns an error if one of the
+// integer parameters of an effect is negative.
+func nonNegative(e Effect, params ...param) error {
+	for _, p := range params {
+		if p.value < 0 {
+			return fmt.Errorf("effect %s: %s cannot be negative", e.Name(), p.name)
+		}
+	}
+	return nil
+}
+
+// orDefault returns v, or def if v is null.
+func orDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// orDefaultFloat returns v, or def if v is null.
+func orDefaultFloat(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// NewSource returns a frame source that computes the frames of a cycle of
+// an effect on the fly, for the given number of pixels. Rewinding the
+// source after a complete cycle does not reset the generator of the effect,
+// so that the random effects, such as Fire or Twinkle, keep evolving when
+// the source is played repeatedly.
+func NewSource(e Effect, pixelCount uint) blinky.SizedFrameSource {
+	return &source{
+		effect:     e,
+		pixelCount: pixelCount,
+		next:       e.Generator(pixelCount),
+		len:        e.Len(pixelCount),
+	}
+}
+
+// source is a FrameSource backed by an effect.
+type source struct {
+	effect     Effect
+	pixelCount uint
+	next       Generator
+	len, pos   int
+}
+
+func (s *source) Next() (blinky.Frame, bool) {
+	if s.pos >= s.len || s.pixelCount == 0 {
+		return nil, false
+	}
+	s.pos++
+	return s.next(), true
+}
+
+func (s *source) Reset() {
+	// the generator is only restarted
+	// if its cycle was interrupted
+	if s.pos != 0 && s.pos < s.len {
+		s.next = s.effect.Generator(s.pixelCount)
+	}
+	s.pos = 0
+}
+
+func (s *source) Len() int {
+	return s.len
+}
@@ -0,0 +1,177 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package effects
+
+import (
+	"math/rand"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// Twinkle lights random pixels of the LED strip,
+// which then fade out progressively.
+type Twinkle struct {
+	// Color is the color of the twinkles. If null,
+	// each twinkle has a random hue.
+	Color blinky.Color `json:"color"`
+	// Density is the probability that a pixel lights up
+	// at each frame, between 0 and 1. Defaults to 0.05.
+	Density float64 `json:"density,omitempty"`
+	// Fade is the part of its brightness a pixel keeps at
+	// each frame, between 0 and 1. Defaults to 0.85.
+	Fade float64 `json:"fade,omitempty"`
+	// Frames is the number of frames of the pattern. Defaults to 100.
+	Frames int `json:"frames,omitempty"`
+	// Seed initializes the random generator.
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (t Twinkle) Name() string { return "twinkle" }
+
+// Validate implements the Validator interface.
+func (t Twinkle) Validate() error {
+	return nonNegative(t, param{"frames", t.Frames})
+}
+
+// Len implements the Effect interface.
+func (t Twinkle) Len(pixelCount uint) int {
+	return orDefault(t.Frames, 100)
+}
+
+// Generator implements the Effect interface.
+func (t Twinkle) Generator(pixelCount uint) Generator {
+	var (
+		density = orDefaultFloat(t.Density, 0.05)
+		fade    = orDefaultFloat(t.Fade, 0.85)
+		rng     = rand.New(rand.NewSource(t.Seed))
+		f       = make(blinky.Frame, pixelCount)
+	)
+	return func() blinky.Frame {
+		for i := range f {
+			f[i].Color = f[i].Color.Scale(fade)
+
+			if rng.Float64() < density {
+				if t.Color == (blinky.Color{}) {
+					f[i].Color = blinky.NewHSVColor(rng.Float64()*360, 1, 1)
+				} else {
+					f[i].Color = t.Color
+				}
+			}
+		}
+		return append(blinky.Frame(nil), f...)
+	}
+}
+
+// Fire simulates flames rising from the beginning of the
+// LED strip, based on the Fire2012 algorithm of FastLED.
+type Fire struct {
+	// Cooling is how much the air cools as it rises, between
+	// 20 and 100 for sensible results. Defaults to 55.
+	Cooling int `json:"cooling,omitempty"`
+	// Sparking is the chance, out of 255, that a new spark is
+	// lit at each frame, between 50 and 200 for sensible results.
+	// Defaults to 120.
+	Sparking int `json:"sparking,omitempty"`
+	// Frames is the number of frames of the pattern. Defaults to 200.
+	Frames int `json:"frames,omitempty"`
+	// Seed initializes the random generator.
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// Name implements the Effect interface.
+func (fi Fire) Name() string { return "fire" }
+
+// Validate implements the Validator interface.
+func (fi Fire) Validate() error {
+	return nonNegative(fi, param{"cooling", fi.Cooling}, param{"sparking", fi.Sparking}, param{"frames", fi.Frames})
+}
+
+// Len implements the Effect interface.
+func (fi Fire) Len(pixelCount uint) int {
+	return orDefault(fi.Frames, 200)
+}
+
+// Generator implements the Effect interface.
+func (fi Fire) Generator(pixelCount uint) Generator {
+	var (
+		cooling  = orDefault(fi.Cooling, 55)
+		sparking = orDefault(fi.Sparking, 120)
+		rng      = rand.New(rand.NewSource(fi.Seed))
+		heat     = make([]int, pixelCount)
+		n        = len(heat)
+	)
+	return func() blinky.Frame {
+		// cool down every cell a little
+		for i := range heat {
+			heat[i] -= rng.Intn(cooling*10/n + 2)
+			if heat[i] < 0 {
+				heat[i] = 0
+			}
+		}
+		// heat drifts up and diffuses a little
+		for i := n - 1; i >= 2; i-- {
+			heat[i] = (heat[i-1] + 2*heat[i-2]) / 3
+		}
+		// randomly ignite new sparks near the bottom
+		if rng.Intn(256) < sparking {
+			y := rng.Intn(minInt(7, n))
+			heat[y] += 160 + rng.Intn(96)
+			if heat[y] > 255 {
+				heat[y] = 255
+			}
+		}
+		f := make(blinky.Frame, pixelCount)
+		for i, h := range heat {
+			f[i].Color = heatColor(byte(h))
+		}
+		return f
+	}
+}
+
+// heatColor returns the color of a temperature, from
+// black to red, yellow and white as it gets hotter.
+func heatColor(temp byte) blinky.Color {
+	// scale the temperature down from 0-255 to 0-191,
+	// which can then be split into three ramps of 64
+	t := int(temp) * 191 / 255
+	ramp := byte((t & 0x3F) << 2)
+
+	switch {
+	case t&0x80 != 0:
+		return blinky.NewRGBColor(255, 255, ramp)
+	case t&0x40 != 0:
+		return blinky.NewRGBColor(255, ramp, 0)
+	default:
+		return blinky.NewRGBColor(ramp, 0, 0)
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -38,6 +38,37 @@ var (
 	// a BlinkyTape instance.
 	ErrNoPixels = errors.New("number of pixels cannot be null")
 
+	// ErrNilTransport is returned when a nil transport is used to create
+	// a BlinkyTape instance.
+	ErrNilTransport = errors.New("transport cannot be nil")
+
+	// ErrTransportClosed is returned when using a transport that is closed.
+	ErrTransportClosed = errors.New("transport is closed")
+
+	// ErrStopped is the error of an animation that was stopped,
+	// or superseded by another one, before its end.
+	ErrStopped = errors.New("animation was stopped")
+
+	// ErrDisconnected is returned when writing to a LED strip
+	// whose transport is being reopened.
+	ErrDisconnected = errors.New("led strip is disconnected")
+
+	// ErrNilOpenFunc is returned when the automatic reconnection
+	// is enabled without a function to reopen the transport.
+	ErrNilOpenFunc = errors.New("reconnect open function cannot be nil")
+
+	// ErrNoDevice is returned when no BlinkyTape
+	// LED strip is found by the discovery.
+	ErrNoDevice = errors.New("no led strip found")
+
+	// ErrDiscoveryUnsupported is returned when the discovery of
+	// the LED strips is not supported by the operating system.
+	ErrDiscoveryUnsupported = errors.New("device discovery is not supported on this platform")
+
+	// ErrNoStrips is returned when a MultiTape is
+	// created without strips, or with a nil one.
+	ErrNoStrips = errors.New("multi tape needs at least one led strip")
+
 	// ErrEmptyBuffer is returned when an attempt to send accumulated data to the
 	// led strip find an empty buffer.
 	ErrEmptyBuffer = errors.New("nothing to render, the buffer is empty")
@@ -50,6 +81,20 @@ var (
 	// the led strip available pixels.
 	ErrOutOfRange = errors.New("attempting to set pixel outside of range")
 
+	// ErrInvalidSampling is returned when the sampling of an image is
+	// invalid, or falls outside of its bounds.
+	ErrInvalidSampling = errors.New("invalid sampling of the image")
+
+	// ErrEmptyPlaylistEntry is returned when an entry of a playlist
+	// has neither an animation nor a file.
+	ErrEmptyPlaylistEntry = errors.New("playlist entry has no animation")
+
+	// ErrUnknownEffect is returned when an effect is not registered.
+	ErrUnknownEffect = errors.New("unknown effect")
+
+	// ErrUnknownPalette is returned when a palette is not registered.
+	ErrUnknownPalette = errors.New("unknown palette")
+
 	// ErrUnknownColorName is returned when a named color is unknown.
 	ErrUnknownColorName = errors.New("unknown color name")
 )
@@ -0,0 +1,139 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType represents the type of an event of a playback.
+type EventType int
+
+// Types of the events of a playback.
+const (
+	// EventStarted is emitted when a playback starts.
+	EventStarted EventType = iota
+	// EventFrame is emitted after each frame rendered.
+	EventFrame
+	// EventError is emitted when a frame cannot be rendered.
+	EventError
+	// EventPaused is emitted when a playback is paused.
+	EventPaused
+	// EventResumed is emitted when a playback is resumed.
+	EventResumed
+	// EventStopped is emitted when a playback is stopped before its end.
+	EventStopped
+	// EventFinished is emitted when all the frames of a playback were played.
+	EventFinished
+)
+
+var eventNames = map[EventType]string{
+	EventStarted:  "started",
+	EventFrame:    "frame",
+	EventError:    "error",
+	EventPaused:   "paused",
+	EventResumed:  "resumed",
+	EventStopped:  "stopped",
+	EventFinished: "finished",
+}
+
+// String implements the fmt.Stringer interface.
+func (et EventType) String() string {
+	if name, ok := eventNames[et]; ok {
+		return name
+	}
+	return fmt.Sprintf("EventType(%d)", int(et))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (et EventType) MarshalText() ([]byte, error) {
+	if _, ok := eventNames[et]; !ok {
+		return nil, fmt.Errorf("unknown event type %d", int(et))
+	}
+	return []byte(et.String()), nil
+}
+
+// An Event reports a change in a playback of the LED strip.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Playback is the playback the event belongs to.
+	Playback *Playback
+	// Frame is the index of the frame rendered, and Repeat the index of
+	// the repetition, for the events of type EventFrame and EventError.
+	Frame  int
+	Repeat int
+	// Err is the error of the render of a frame, or of a playlist
+	// entry that cannot be played, for the events of type EventError,
+	// and the error that ended the playback, for the events of type
+	// EventStopped.
+	Err error
+}
+
+// An EventHandler handles the events of the playbacks of a LED strip.
+type EventHandler func(Event)
+
+// SetEventHandler sets the function called with the events of the
+// playbacks of the LED strip, or removes it if nil. The handler is
+// called synchronously by the animation loop, so it should return
+// quickly, and must not call the controls of the LED strip.
+// A stream of frames only reports its start and its end.
+func (bt *BlinkyTape) SetEventHandler(h EventHandler) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	bt.eventHandler = h
+}
+
+// emit sends an event of a session to the event handler.
+func (bt *BlinkyTape) emit(s *session, et EventType) {
+	e := Event{Type: et}
+	if et == EventStopped {
+		e.Err = s.err
+	}
+	bt.emitEvent(e, s)
+}
+
+// emitFrame sends the event of a frame rendered to the event handler.
+func (bt *BlinkyTape) emitFrame(s *session, frame int) {
+	bt.emitEvent(Event{Type: EventFrame, Frame: frame, Repeat: s.repeat}, s)
+}
+
+// emitError sends the error of the render of a frame to the event handler.
+func (bt *BlinkyTape) emitError(s *session, frame int, err error) {
+	bt.emitEvent(Event{Type: EventError, Frame: frame, Repeat: s.repeat, Err: err}, s)
+}
+
+func (bt *BlinkyTape) emitEvent(e Event, s *session) {
+	bt.mutex.Lock()
+	h := bt.eventHandler
+	bt.mutex.Unlock()
+
+	if h != nil {
+		e.Time = time.Now()
+		e.Playback = s.Playback
+		h(e)
+	}
+}
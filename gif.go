@@ -0,0 +1,214 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"image"
+	"image/draw"
+	"image/gif"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sampling mode constants.
+const (
+	// SampleRow samples the pixels along a row of the image.
+	SampleRow SamplingMode = iota
+	// SampleColumn samples the pixels along a column of the image.
+	SampleColumn
+	// SampleLine samples the pixels along an arbitrary line.
+	SampleLine
+)
+
+// SamplingMode represents the way the pixels of a frame
+// are sampled from an image.
+type SamplingMode int
+
+// A Sampling describes where the pixels of a frame are sampled
+// from an image. Index is the row or column to sample, and From
+// and To are the ends of the line to sample, depending on the mode.
+// The points are evenly distributed along the row, column or line,
+// including both ends.
+type Sampling struct {
+	Mode     SamplingMode
+	Index    int
+	From, To image.Point
+}
+
+// line returns the ends of the line to sample in the given bounds.
+func (s Sampling) line(bounds image.Rectangle) (image.Point, image.Point, error) {
+	var from, to image.Point
+
+	switch s.Mode {
+	case SampleRow:
+		from = image.Pt(bounds.Min.X, bounds.Min.Y+s.Index)
+		to = image.Pt(bounds.Max.X-1, bounds.Min.Y+s.Index)
+	case SampleColumn:
+		from = image.Pt(bounds.Min.X+s.Index, bounds.Min.Y)
+		to = image.Pt(bounds.Min.X+s.Index, bounds.Max.Y-1)
+	case SampleLine:
+		from, to = s.From, s.To
+	default:
+		return from, to, ErrInvalidSampling
+	}
+	if !from.In(bounds) || !to.In(bounds) {
+		return from, to, ErrInvalidSampling
+	}
+	return from, to, nil
+}
+
+// NewAnimationFromGIF returns a new animation created from an
+// animated GIF. Each image of the GIF becomes a frame of the
+// pattern, whose pixels are sampled as described by s.
+// The disposal methods of the images are honored, and their
+// delays are used as the durations of the frames. The number
+// of repetitions is derived from the loop count of the GIF.
+func NewAnimationFromGIF(path string, pixelCount uint, s Sampling) (*Animation, error) {
+	if pixelCount == 0 {
+		return nil, ErrNoPixels
+	}
+	g, err := readGIF(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	from, to, err := s.line(bounds)
+	if err != nil {
+		return nil, err
+	}
+	points := samplePoints(from, to, pixelCount)
+
+	anim := gifAnimation(path, g)
+	composeGIF(g, func(i int, canvas *image.RGBA) {
+		f := make(Frame, pixelCount)
+		for j, p := range points {
+			c := canvas.RGBAAt(p.X, p.Y)
+			f[j] = Pixel{
+				Color: NewRGBColor(c.R, c.G, c.B),
+			}
+		}
+		anim.Pattern[i] = f
+	})
+	return anim, nil
+}
+
+// readGIF decodes all the images of a GIF file.
+func readGIF(path string) (*gif.GIF, error) {
+	reader, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return gif.DecodeAll(reader)
+}
+
+// gifAnimation returns an animation with room for a frame per
+// image of a GIF, whose durations are the delays of the images,
+// and whose number of repetitions is derived from its loop count.
+func gifAnimation(path string, g *gif.GIF) *Animation {
+	anim := &Animation{
+		Name:      strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Repeat:    gifRepeat(g.LoopCount),
+		Durations: make([]uint, len(g.Image)),
+		Pattern:   make(Pattern, len(g.Image)),
+	}
+	// GIF delays are expressed in hundredths of second
+	for i := range anim.Durations {
+		if i < len(g.Delay) {
+			anim.Durations[i] = uint(g.Delay[i]) * 10
+		}
+	}
+	return anim
+}
+
+// composeGIF draws the images of a GIF one after the other on a
+// canvas, and calls fn with the canvas once each image is drawn.
+// The disposal methods of the images are honored.
+func composeGIF(g *gif.GIF, fn func(i int, canvas *image.RGBA)) {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+
+	// the canvas accumulates the images of the GIF,
+	// each one being drawn over the previous ones
+	canvas := image.NewRGBA(bounds)
+	var previous *image.RGBA
+
+	for i, img := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			draw.Draw(previous, bounds, canvas, bounds.Min, draw.Src)
+		}
+		draw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, draw.Over)
+
+		fn(i, canvas)
+
+		// dispose of the image before drawing the next one
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas, previous = previous, nil
+		}
+	}
+}
+
+// samplePoints returns n points evenly
+// distributed along the line [from, to].
+func samplePoints(from, to image.Point, n uint) []image.Point {
+	points := make([]image.Point, n)
+
+	for i := range points {
+		var t float64
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		points[i] = image.Pt(
+			from.X+int(math.Floor(t*float64(to.X-from.X)+0.5)),
+			from.Y+int(math.Floor(t*float64(to.Y-from.Y)+0.5)),
+		)
+	}
+	return points
+}
+
+// gifRepeat converts the loop count of a GIF to a number of
+// repetitions. A GIF with a null loop count loops forever, and
+// a GIF with a negative loop count is shown only once.
+func gifRepeat(loopCount int) int {
+	switch {
+	case loopCount == 0:
+		return -1
+	case loopCount < 0:
+		return 1
+	default:
+		return loopCount + 1
+	}
+}
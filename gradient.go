@@ -0,0 +1,203 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Interpolation constants.
+const (
+	// InterpolateRGB interpolates the red, green and blue
+	// components of the colors.
+	InterpolateRGB Interpolation = iota
+	// InterpolateHSV interpolates the hue, the saturation and the
+	// value of the colors, the hue going the shortest way around
+	// the color wheel.
+	InterpolateHSV
+)
+
+// Interpolation represents the color space in which
+// the colors of a gradient are interpolated.
+type Interpolation int
+
+var interpolationNames = map[Interpolation]string{
+	InterpolateRGB: "rgb",
+	InterpolateHSV: "hsv",
+}
+
+// String implements the fmt.Stringer interface.
+func (i Interpolation) String() string {
+	if name, ok := interpolationNames[i]; ok {
+		return name
+	}
+	return fmt.Sprintf("Interpolation(%d)", int(i))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (i Interpolation) MarshalText() ([]byte, error) {
+	if _, ok := interpolationNames[i]; !ok {
+		return nil, fmt.Errorf("unknown interpolation %d", int(i))
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (i *Interpolation) UnmarshalText(text []byte) error {
+	for interp, name := range interpolationNames {
+		if name == string(text) {
+			*i = interp
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown interpolation %q", text)
+}
+
+// A GradientStop is a color of a gradient, at a
+// position between 0 (start) and 1 (end).
+type GradientStop struct {
+	Position float64 `json:"position"`
+	Color    Color   `json:"color"`
+}
+
+// A Gradient is a progression of colors defined by its stops.
+// The colors before the first stop and after the last one are
+// the colors of these stops.
+type Gradient struct {
+	Stops         []GradientStop `json:"stops"`
+	Interpolation Interpolation  `json:"interpolation"`
+}
+
+// NewGradient returns a new gradient whose stops are the
+// given colors, evenly distributed from start to end.
+func NewGradient(colors ...Color) Gradient {
+	g := Gradient{Stops: make([]GradientStop, len(colors))}
+	for i, c := range colors {
+		g.Stops[i].Color = c
+		if len(colors) > 1 {
+			g.Stops[i].Position = float64(i) / float64(len(colors)-1)
+		}
+	}
+	return g
+}
+
+// At returns the color of the gradient at the given
+// position, between 0 (start) and 1 (end).
+func (g Gradient) At(t float64) Color {
+	return g.at(g.sortedStops(), t)
+}
+
+// sortedStops returns the stops of the gradient sorted by position.
+// They are only copied if they are not already sorted.
+func (g Gradient) sortedStops() []GradientStop {
+	less := func(s []GradientStop) func(i, j int) bool {
+		return func(i, j int) bool {
+			return s[i].Position < s[j].Position
+		}
+	}
+	if sort.SliceIsSorted(g.Stops, less(g.Stops)) {
+		return g.Stops
+	}
+	stops := append([]GradientStop(nil), g.Stops...)
+	sort.SliceStable(stops, less(stops))
+
+	return stops
+}
+
+// at returns the color of the gradient at the
+// given position, from its sorted stops.
+func (g Gradient) at(stops []GradientStop, t float64) Color {
+	if len(stops) == 0 {
+		return Color{}
+	}
+	if t <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		from, to := stops[i-1], stops[i]
+		if t > to.Position {
+			continue
+		}
+		d := to.Position - from.Position
+		if d == 0 {
+			return to.Color
+		}
+		return g.interpolate(from.Color, to.Color, (t-from.Position)/d)
+	}
+	return stops[len(stops)-1].Color
+}
+
+// interpolate interpolates two colors in the color space of the gradient.
+func (g Gradient) interpolate(a, b Color, t float64) Color {
+	if g.Interpolation != InterpolateHSV {
+		return a.Lerp(b, t)
+	}
+	ha, sa, va := a.HSV()
+	hb, sb, vb := b.HSV()
+
+	// the hue of a gray is meaningless, use the one of the other color
+	if sa == 0 {
+		ha = hb
+	}
+	if sb == 0 {
+		hb = ha
+	}
+	// go the shortest way around the color wheel
+	dh := math.Mod(hb-ha+540, 360) - 180
+
+	return NewHSVColor(ha+dh*t, sa+(sb-sa)*t, va+(vb-va)*t)
+}
+
+// Frame returns a frame of the given number of pixels
+// filled with the gradient, from its start to its end.
+func (g Gradient) Frame(pixelCount uint) Frame {
+	stops := g.sortedStops()
+
+	f := make(Frame, pixelCount)
+	for i := range f {
+		var t float64
+		if pixelCount > 1 {
+			t = float64(i) / float64(pixelCount-1)
+		}
+		f[i].Color = g.at(stops, t)
+	}
+	return f
+}
+
+// Palette returns a palette of the given number of
+// colors sampled from the start to the end of the gradient.
+func (g Gradient) Palette(size int) Palette {
+	if size < 0 {
+		size = 0
+	}
+	f := g.Frame(uint(size))
+	p := Palette{Colors: make([]Color, len(f))}
+	for i, px := range f {
+		p.Colors[i] = px.Color
+	}
+	return p
+}
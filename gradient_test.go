@@ -0,0 +1,71 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "testing"
+
+func TestGradientUnsortedStops(t *testing.T) {
+	red, blue := NewRGBColor(255, 0, 0), NewRGBColor(0, 0, 255)
+	g := Gradient{Stops: []GradientStop{{1, blue}, {0, red}}}
+
+	tests := []struct {
+		pos  float64
+		want Color
+	}{
+		{-1, red},
+		{0, red},
+		{0.5, Color{R: 128, B: 128}},
+		{1, blue},
+		{2, blue},
+	}
+	for _, tt := range tests {
+		if got := g.At(tt.pos); got != tt.want {
+			t.Errorf("At(%v) = %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+	// the stops of the gradient are left untouched
+	if g.Stops[0].Color != blue {
+		t.Errorf("stops were sorted in place")
+	}
+}
+
+func TestPaletteSampled(t *testing.T) {
+	g := NewGradient(NewRGBColor(255, 0, 0), NewRGBColor(0, 255, 0), NewRGBColor(0, 0, 255))
+	p := Palette{Gradient: &g}
+	s := p.Sampled()
+
+	if len(s.Colors) != PaletteDefaultSize || s.Gradient != nil {
+		t.Fatalf("sampled palette has %d colors and gradient %v", len(s.Colors), s.Gradient)
+	}
+	for i := 0; i < 100; i++ {
+		pos := float64(i) / 100
+		if got, want := s.At(pos), p.At(pos); got != want {
+			t.Errorf("At(%v) = %v, want %v", pos, got, want)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { s.At(0.3) }); n != 0 {
+		t.Errorf("At allocates %v times, want 0", n)
+	}
+}
@@ -0,0 +1,244 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/nfnt/resize"
+)
+
+// Wiring constants.
+const (
+	// WiringProgressive means all the rows of the matrix
+	// are wired from left to right.
+	WiringProgressive Wiring = iota
+	// WiringSerpentine means the rows of the matrix are wired
+	// alternately from left to right, and from right to left,
+	// starting from left to right.
+	WiringSerpentine
+)
+
+// Wiring represents the way a LED strip is folded into the rows of a matrix.
+type Wiring int
+
+// Rotation constants, clockwise.
+const (
+	// Rotate0 draws the pixels upright.
+	Rotate0 Rotation = iota
+	// Rotate90 rotates the pixels by 90 degrees.
+	Rotate90
+	// Rotate180 rotates the pixels by 180 degrees.
+	Rotate180
+	// Rotate270 rotates the pixels by 270 degrees.
+	Rotate270
+)
+
+// Rotation represents the rotation of the
+// pixels drawn on a matrix, clockwise.
+type Rotation int
+
+// A Matrix describes a LED strip folded into a panel of Width
+// pixels by Height pixels, the first pixel of the strip being
+// at the top left corner of the panel. It maps the coordinates
+// of the pixels drawn on the panel onto the indexes of the strip.
+//
+// The pixels drawn are flipped horizontally and vertically, then
+// rotated. Once rotated by 90 or 270 degrees, the width and the
+// height of the drawing surface are swapped, see Size.
+type Matrix struct {
+	Width, Height uint
+	Wiring        Wiring
+	Rotation      Rotation
+	FlipX, FlipY  bool
+}
+
+// Len returns the number of pixels of the matrix.
+func (m Matrix) Len() uint {
+	return m.Width * m.Height
+}
+
+// Size returns the width and the height of the drawing
+// surface of the matrix, once rotated.
+func (m Matrix) Size() (int, int) {
+	if m.Rotation == Rotate90 || m.Rotation == Rotate270 {
+		return int(m.Height), int(m.Width)
+	}
+	return int(m.Width), int(m.Height)
+}
+
+// Index returns the index in the LED strip of the pixel drawn at
+// the given coordinates, and false if they are outside the matrix.
+func (m Matrix) Index(x, y int) (uint, bool) {
+	w, h := m.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return 0, false
+	}
+	if m.FlipX {
+		x = w - 1 - x
+	}
+	if m.FlipY {
+		y = h - 1 - y
+	}
+	px, py := x, y
+	switch m.Rotation {
+	case Rotate90:
+		px, py = int(m.Width)-1-y, x
+	case Rotate180:
+		px, py = int(m.Width)-1-x, int(m.Height)-1-y
+	case Rotate270:
+		px, py = y, int(m.Height)-1-x
+	}
+	if m.Wiring == WiringSerpentine && py%2 == 1 {
+		px = int(m.Width) - 1 - px
+	}
+	return uint(py)*m.Width + uint(px), true
+}
+
+// A MatrixFrame is a frame drawn on a matrix, as a list of
+// rows of pixels, from top to bottom.
+type MatrixFrame [][]Pixel
+
+// NewFrame returns a new frame of the size of the drawing
+// surface of the matrix, with all pixels set to black.
+func (m Matrix) NewFrame() MatrixFrame {
+	w, h := m.Size()
+	mf := make(MatrixFrame, h)
+	for y := range mf {
+		mf[y] = make([]Pixel, w)
+	}
+	return mf
+}
+
+// Set sets the pixel at the given coordinates. The
+// coordinates outside the frame are ignored.
+func (mf MatrixFrame) Set(x, y int, p Pixel) {
+	if y >= 0 && y < len(mf) && x >= 0 && x < len(mf[y]) {
+		mf[y][x] = p
+	}
+}
+
+// Flatten returns the frame to render on the LED strip to display
+// a frame drawn on the matrix. The pixels outside the matrix are
+// ignored, and the pixels missing are black.
+func (m Matrix) Flatten(mf MatrixFrame) Frame {
+	f := make(Frame, m.Len())
+	for y, row := range mf {
+		for x, p := range row {
+			if i, ok := m.Index(x, y); ok {
+				f[i] = p
+			}
+		}
+	}
+	return f
+}
+
+// FlattenImage returns the frame to render on the LED strip to display
+// an image on the matrix. The image is resized to the size of the
+// drawing surface of the matrix, regardless its aspect ratio.
+func (m Matrix) FlattenImage(img image.Image) Frame {
+	w, h := m.Size()
+	if w == 0 || h == 0 {
+		return make(Frame, m.Len())
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() != w || bounds.Dy() != h {
+		img = resize.Resize(uint(w), uint(h), img, resize.Bilinear)
+	}
+
+	// draw a new rgba image from source, so we can
+	// directly access to the rgb representation of each pixel
+	bounds = img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+
+	mf := m.NewFrame()
+	for y := range mf {
+		for x := range mf[y] {
+			c := rgba.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			mf[y][x] = Pixel{
+				Color: NewRGBColor(c.R, c.G, c.B),
+			}
+		}
+	}
+	return m.Flatten(mf)
+}
+
+// Pattern returns the pattern to play on the LED
+// strip to display frames drawn on the matrix.
+func (m Matrix) Pattern(frames []MatrixFrame) Pattern {
+	p := make(Pattern, len(frames))
+	for i, mf := range frames {
+		p[i] = m.Flatten(mf)
+	}
+	return p
+}
+
+// NewFrameFromImage returns the frame to render on the LED strip
+// to display an image file on the matrix, see FlattenImage.
+// Types 'jpeg', 'png', 'gif' and 'bmp' are supported.
+func (m Matrix) NewFrameFromImage(path string) (Frame, error) {
+	img, err := readImage(path)
+	if err != nil {
+		return nil, err
+	}
+	return m.FlattenImage(img), nil
+}
+
+// NewAnimationFromGIF returns a new animation created from an animated
+// GIF, each image of the GIF being displayed on the matrix. The disposal
+// methods of the images are honored, and their delays are used as the
+// durations of the frames. The number of repetitions is derived from
+// the loop count of the GIF.
+func (m Matrix) NewAnimationFromGIF(path string) (*Animation, error) {
+	if m.Len() == 0 {
+		return nil, ErrNoPixels
+	}
+	g, err := readGIF(path)
+	if err != nil {
+		return nil, err
+	}
+	anim := gifAnimation(path, g)
+	composeGIF(g, func(i int, canvas *image.RGBA) {
+		anim.Pattern[i] = m.FlattenImage(canvas)
+	})
+	return anim, nil
+}
+
+// SetPixelXY sets the pixel drawn at the given coordinates of a matrix.
+func (bt *BlinkyTape) SetPixelXY(m Matrix, x, y int, p Pixel) error {
+	i, ok := m.Index(x, y)
+	if !ok || i >= bt.PixelCount {
+		return ErrOutOfRange
+	}
+	return bt.SetPixelAt(&p, i)
+}
+
+// DrawImage sets the pixels of the LED strip to display an
+// image on a matrix, see Matrix.FlattenImage.
+func (bt *BlinkyTape) DrawImage(m Matrix, img image.Image) error {
+	return bt.SetPixels(m.FlattenImage(img))
+}
@@ -0,0 +1,98 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// indexes returns the indexes of the pixels of the drawing
+// surface of a matrix, as a list of rows from top to bottom.
+func indexes(m Matrix) [][]uint {
+	w, h := m.Size()
+	rows := make([][]uint, h)
+	for y := range rows {
+		rows[y] = make([]uint, w)
+		for x := range rows[y] {
+			i, ok := m.Index(x, y)
+			if !ok {
+				panic(fmt.Sprintf("(%d, %d) is outside the matrix", x, y))
+			}
+			rows[y][x] = i
+		}
+	}
+	return rows
+}
+
+func TestMatrixIndex(t *testing.T) {
+	// a panel of 3x2 pixels, whose strip indexes are
+	//   0 1 2        0 1 2
+	//   3 4 5        5 4 3
+	// when progressive, and serpentine
+	tests := []struct {
+		name   string
+		matrix Matrix
+		want   [][]uint
+	}{
+		{"progressive", Matrix{Width: 3, Height: 2}, [][]uint{{0, 1, 2}, {3, 4, 5}}},
+		{"serpentine", Matrix{Width: 3, Height: 2, Wiring: WiringSerpentine}, [][]uint{{0, 1, 2}, {5, 4, 3}}},
+		{"flip x", Matrix{Width: 3, Height: 2, FlipX: true}, [][]uint{{2, 1, 0}, {5, 4, 3}}},
+		{"flip y", Matrix{Width: 3, Height: 2, FlipY: true}, [][]uint{{3, 4, 5}, {0, 1, 2}}},
+		{"rotate 90", Matrix{Width: 3, Height: 2, Rotation: Rotate90}, [][]uint{{2, 5}, {1, 4}, {0, 3}}},
+		{"rotate 180", Matrix{Width: 3, Height: 2, Rotation: Rotate180}, [][]uint{{5, 4, 3}, {2, 1, 0}}},
+		{"rotate 270", Matrix{Width: 3, Height: 2, Rotation: Rotate270}, [][]uint{{3, 0}, {4, 1}, {5, 2}}},
+		{
+			"serpentine rotate 90",
+			Matrix{Width: 3, Height: 2, Wiring: WiringSerpentine, Rotation: Rotate90},
+			[][]uint{{2, 3}, {1, 4}, {0, 5}},
+		},
+		{
+			"serpentine rotate 270",
+			Matrix{Width: 3, Height: 2, Wiring: WiringSerpentine, Rotation: Rotate270},
+			[][]uint{{5, 0}, {4, 1}, {3, 2}},
+		},
+		{
+			"flip x rotate 90",
+			Matrix{Width: 3, Height: 2, FlipX: true, Rotation: Rotate90},
+			[][]uint{{5, 2}, {4, 1}, {3, 0}},
+		},
+	}
+	for _, tt := range tests {
+		if got := indexes(tt.matrix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: indexes = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatrixIndexOutside(t *testing.T) {
+	m := Matrix{Width: 3, Height: 2, Rotation: Rotate90}
+	for _, p := range [][2]int{{-1, 0}, {0, -1}, {2, 0}, {0, 3}} {
+		if _, ok := m.Index(p[0], p[1]); ok {
+			t.Errorf("Index(%d, %d) is inside the matrix", p[0], p[1])
+		}
+	}
+}
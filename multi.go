@@ -0,0 +1,293 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"io"
+	"sync"
+)
+
+// A Strip is a LED strip that is part of a MultiTape.
+type Strip struct {
+	// Tape is the LED strip
+	Tape *BlinkyTape
+	// Reversed indicates that the first pixel of the LED strip
+	// is at the end of its range in the logical canvas
+	Reversed bool
+}
+
+// A MultiTape aggregates several LED strips into one logical canvas,
+// whose pixels are the pixels of each strip, one strip after the other.
+// It is a BlinkyTape, so all its methods are available: the frames
+// rendered are split across the strips, which are written at once so
+// that they present the same frame at the same time.
+//
+// The strips must not be used directly once they are part of a
+// MultiTape, and closing the MultiTape closes all of them.
+type MultiTape struct {
+	*BlinkyTape
+	canvas *canvas
+}
+
+// NewMultiTape creates a new MultiTape instance that spans the given
+// strips, in order. The number of pixels of the MultiTape is the sum
+// of the number of pixels of the strips.
+func NewMultiTape(strips ...Strip) (*MultiTape, error) {
+	if len(strips) == 0 {
+		return nil, ErrNoStrips
+	}
+	var count uint
+	for _, s := range strips {
+		if s.Tape == nil {
+			return nil, ErrNoStrips
+		}
+		count += s.Tape.PixelCount
+	}
+	c := &canvas{
+		strips: append([]Strip(nil), strips...),
+		pixels: make(Frame, count),
+	}
+	bt, err := NewBlinkyTapeWithTransport(c, count)
+	if err != nil {
+		return nil, err
+	}
+	// the colors are corrected by each strip
+	bt.setColorCorrection(nil)
+
+	return &MultiTape{BlinkyTape: bt, canvas: c}, nil
+}
+
+// Strips returns the strips of the MultiTape, in order.
+func (mt *MultiTape) Strips() []Strip {
+	return append([]Strip(nil), mt.canvas.strips...)
+}
+
+// SetColorCorrection sets the color correction of all the strips
+// of the MultiTape, see BlinkyTape.SetColorCorrection. Each strip
+// can also have its own, to match the others.
+func (mt *MultiTape) SetColorCorrection(cc *ColorCorrection) error {
+	for _, s := range mt.canvas.strips {
+		s.Tape.setColorCorrection(cc)
+	}
+	return mt.refresh()
+}
+
+// SetBrightness sets the brightness of all the strips of the MultiTape,
+// see BlinkyTape.SetBrightness. Each strip scales its colors once they
+// are corrected, like a single LED strip does.
+func (mt *MultiTape) SetBrightness(b float64) error {
+	for _, s := range mt.canvas.strips {
+		s.Tape.setBrightness(b)
+	}
+	return mt.refresh()
+}
+
+// Brightness returns the brightness of the strips of the MultiTape.
+func (mt *MultiTape) Brightness() float64 {
+	return mt.canvas.strips[0].Tape.Brightness()
+}
+
+// SetPixelBrightness sets the brightness of each pixel of the logical
+// canvas, see BlinkyTape.SetPixelBrightness. The list is split across
+// the strips, following their range and direction in the canvas.
+func (mt *MultiTape) SetPixelBrightness(b []float64) error {
+	var off uint
+	for _, s := range mt.canvas.strips {
+		var mask []float64
+		if b != nil && off < uint(len(b)) {
+			mask = make([]float64, s.Tape.PixelCount)
+			for i := range mask {
+				mask[i] = 1
+				if j := off + uint(i); j < uint(len(b)) {
+					mask[i] = b[j]
+				}
+			}
+			if s.Reversed {
+				mask = reverseBrightness(mask)
+			}
+		}
+		off += s.Tape.PixelCount
+		s.Tape.setPixelBrightness(mask)
+	}
+	return mt.refresh()
+}
+
+// reverseBrightness reverses a list of brightness in place.
+func reverseBrightness(b []float64) []float64 {
+	for l, r := 0, len(b)-1; l < r; l, r = l+1, r-1 {
+		b[l], b[r] = b[r], b[l]
+	}
+	return b
+}
+
+// SetPowerLimit sets the current budget of each strip of the
+// MultiTape, see BlinkyTape.SetPowerLimit. The current drawn by
+// a strip is estimated once its colors are corrected, and each
+// strip is dimmed on its own to fit in its budget.
+func (mt *MultiTape) SetPowerLimit(pl *PowerLimit) error {
+	for _, s := range mt.canvas.strips {
+		s.Tape.setPowerLimit(pl)
+	}
+	return mt.refresh()
+}
+
+// canvas is the transport of a MultiTape. It decodes the frames sent
+// to the logical canvas, and renders their slice on each strip.
+type canvas struct {
+	mutex   sync.Mutex
+	strips  []Strip
+	decoder frameDecoder
+	pixels  Frame
+	closed  bool
+}
+
+// Write decodes the data sent to the canvas. Each time a frame is
+// complete, all the strips are rendered with their slice of the
+// canvas, and the first error that occurred is returned.
+func (c *canvas) Write(data []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return 0, ErrTransportClosed
+	}
+	var err error
+	c.decoder.decode(data, func(f Frame) {
+		// like the real device, the pixels not
+		// received keep their previous state
+		copy(c.pixels, f)
+		unclamp(c.pixels)
+		if errP := c.present(); errP != nil && err == nil {
+			err = errP
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// unclamp restores the full intensity of the channels of the pixels
+// sent to the canvas, which the protocol clamps to 254, before the
+// colors are corrected by the strips.
+func unclamp(f Frame) {
+	for i, p := range f {
+		for _, v := range []*byte{&p.Color.R, &p.Color.G, &p.Color.B} {
+			if *v == ControlHeader-1 {
+				*v = ControlHeader
+			}
+		}
+		f[i] = p
+	}
+}
+
+// present renders the slice of the canvas of each strip. The strips
+// are written concurrently, so that they render the frame at once.
+func (c *canvas) present() error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(c.strips))
+		off  uint
+	)
+	for i, s := range c.strips {
+		seg := c.pixels[off : off+s.Tape.PixelCount].copy()
+		off += s.Tape.PixelCount
+
+		if s.Reversed {
+			for l, r := 0, len(seg)-1; l < r; l, r = l+1, r-1 {
+				seg[l], seg[r] = seg[r], seg[l]
+			}
+		}
+		wg.Add(1)
+		go func(i int, bt *BlinkyTape, seg Frame) {
+			defer wg.Done()
+			errs[i] = bt.present(seg)
+		}(i, s.Tape, seg)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// present sends a frame to the LED strip, and makes it its current
+// state, regardless of the pixels accumulated in its buffer.
+func (bt *BlinkyTape) present(f Frame) error {
+	triplets := make([]byte, 0, len(f)*3)
+	for _, p := range f {
+		triplets = append(triplets, p.rgbTriplet()...)
+	}
+	if err := bt.sendBytes(bt.encode(triplets)); err != nil {
+		return err
+	}
+	bt.stateMutex.Lock()
+	copy(bt.currState, f)
+	copy(bt.nextState, f)
+	bt.stateMutex.Unlock()
+
+	return nil
+}
+
+// Read implements the Transport interface. The canvas
+// never sends data back, so it always returns io.EOF.
+func (c *canvas) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+// Flush implements the Transport interface. It does nothing,
+// since the strips are flushed each time they are written.
+func (c *canvas) Flush() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return ErrTransportClosed
+	}
+	return nil
+}
+
+// Close closes all the strips of the canvas,
+// and returns the first error that occurred.
+func (c *canvas) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	var err error
+	for _, s := range c.strips {
+		if errC := s.Tape.Close(); errC != nil && err == nil {
+			err = errC
+		}
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestMultiTape returns a MultiTape that spans two strips of three
+// pixels backed by VirtualTapes, the second one being reversed.
+func newTestMultiTape(t *testing.T) (*MultiTape, []*VirtualTape) {
+	a, va := newTestTape(t, 3)
+	b, vb := newTestTape(t, 3)
+
+	mt, err := NewMultiTape(Strip{Tape: a}, Strip{Tape: b, Reversed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mt, []*VirtualTape{va, vb}
+}
+
+func TestMultiTapeRender(t *testing.T) {
+	mt, vts := newTestMultiTape(t)
+	defer mt.Close()
+
+	f := testFrame(6)
+	for i, p := range f {
+		if err := mt.SetPixelAt(&p, uint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]byte{{1, 2, 3}, {6, 5, 4}}
+	for i, vt := range vts {
+		if got := reds(vt.Pixels()); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("strip %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestMultiTapeBrightness(t *testing.T) {
+	mt, vts := newTestMultiTape(t)
+	defer mt.Close()
+
+	// a single LED strip, corrected like the strips,
+	// renders the colors the strips are compared to
+	ref, vref := newTestTape(t, 1)
+	defer ref.Close()
+
+	cc := DefaultColorCorrection()
+	mt.SetColorCorrection(cc)
+	ref.SetColorCorrection(cc)
+
+	white := NewRGBColor(255, 255, 255)
+	for _, bt := range []*BlinkyTape{mt.BlinkyTape, ref} {
+		if err := bt.SetColor(white); err != nil {
+			t.Fatal(err)
+		}
+		if err := bt.Render(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ref.SetBrightness(0.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.SetBrightness(0.5); err != nil {
+		t.Fatal(err)
+	}
+	want := vref.Pixels()[0].Color
+	for i, vt := range vts {
+		for j, p := range vt.Pixels() {
+			if p.Color != want {
+				t.Errorf("strip %d pixel %d = %v, want %v", i, j, p.Color, want)
+			}
+		}
+	}
+	if b := mt.Brightness(); b != 0.5 {
+		t.Errorf("brightness = %v, want 0.5", b)
+	}
+}
+
+func TestMultiTapePixelBrightness(t *testing.T) {
+	mt, vts := newTestMultiTape(t)
+	defer mt.Close()
+
+	if err := mt.SetColor(NewRGBColor(200, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	// the pixels beyond the list keep their full brightness,
+	// and the ones of the reversed strip are mirrored
+	if err := mt.SetPixelBrightness([]float64{0, 0.5, 1, 0}); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]byte{{0, 100, 200}, {200, 200, 0}}
+	for i, vt := range vts {
+		if got := reds(vt.Pixels()); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("strip %d = %v, want %v", i, got, want[i])
+		}
+	}
+	// the brightness of the MultiTape itself is never applied
+	if b := mt.BlinkyTape.Brightness(); b != 1 {
+		t.Errorf("brightness of the canvas = %v, want 1", b)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"sort"
+	"sync"
+)
+
+// PaletteDefaultSize is the number of colors sampled from the
+// gradient of a palette that has no colors of its own.
+const PaletteDefaultSize = 16
+
+// A Palette is a list of colors that can be sampled at any position,
+// such as the 16 colors palettes of FastLED. The colors are blended
+// from one to the next, the last one blending back into the first one,
+// unless the palette is discrete. A palette can also be described by a
+// gradient, from which PaletteDefaultSize colors are sampled.
+//
+// In JSON, a palette can be replaced by the name of a registered
+// palette, such as "lava" or "ocean".
+type Palette struct {
+	Name     string    `json:"name"`
+	Colors   []Color   `json:"colors,omitempty"`
+	Gradient *Gradient `json:"gradient,omitempty"`
+	Discrete bool      `json:"discrete,omitempty"`
+}
+
+// colors returns the colors of the palette, or the
+// ones sampled from its gradient if it has none.
+func (p Palette) colors() []Color {
+	if len(p.Colors) != 0 || p.Gradient == nil {
+		return p.Colors
+	}
+	return p.Gradient.Palette(PaletteDefaultSize).Colors
+}
+
+// Sampled returns a copy of the palette whose colors are sampled from
+// its gradient, if it has no colors of its own, so that they are not
+// sampled again by each call to At.
+func (p Palette) Sampled() Palette {
+	if len(p.Colors) != 0 || p.Gradient == nil {
+		return p
+	}
+	p.Colors = p.colors()
+	p.Gradient = nil
+
+	return p
+}
+
+// At returns the color of the palette at the given position. The
+// position wraps around the palette: 0 is its first color, and 1
+// is its first color again. The colors of a palette described by
+// a gradient are sampled at each call, see Sampled.
+func (p Palette) At(t float64) Color {
+	colors := p.colors()
+	if len(colors) == 0 {
+		return Color{}
+	}
+	pos := (t - math.Floor(t)) * float64(len(colors))
+	i := int(pos) % len(colors)
+
+	if p.Discrete {
+		return colors[i]
+	}
+	return colors[i].Lerp(colors[(i+1)%len(colors)], pos-math.Floor(pos))
+}
+
+// Frame returns a frame of the given number of
+// pixels filled with a full cycle of the palette.
+func (p Palette) Frame(pixelCount uint) Frame {
+	p = p.Sampled()
+
+	f := make(Frame, pixelCount)
+	for i := range f {
+		f[i].Color = p.At(float64(i) / float64(pixelCount))
+	}
+	return f
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A palette
+// is decoded from its JSON object, or from the name of a registered
+// palette.
+func (p *Palette) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		registered, err := LookupPalette(name)
+		if err != nil {
+			return err
+		}
+		*p = registered
+		return nil
+	}
+	// the alias type doesn't have the method, which avoids the recursion
+	type palette Palette
+	return json.Unmarshal(data, (*palette)(p))
+}
+
+// NewPaletteFromFile creates a new Palette instance from a file.
+// The palette file must use JSON as its marshalling format.
+func NewPaletteFromFile(path string) (*Palette, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := Palette{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SaveToFile marshall a Palette to JSON format and
+// write it to a file.
+func (p Palette) SaveToFile(path string) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+var (
+	palettes      = make(map[string]Palette)
+	palettesMutex sync.RWMutex
+)
+
+// RegisterPalette registers a palette under its name, so that it can be
+// referenced by its name in JSON. The palettes of FastLED are registered
+// as "rainbow", "party", "cloud", "lava", "ocean", "forest" and "heat".
+func RegisterPalette(p Palette) {
+	palettesMutex.Lock()
+	defer palettesMutex.Unlock()
+	palettes[p.Name] = p
+}
+
+// LookupPalette returns the palette registered with the given name.
+func LookupPalette(name string) (Palette, error) {
+	palettesMutex.RLock()
+	defer palettesMutex.RUnlock()
+
+	p, ok := palettes[name]
+	if !ok {
+		return Palette{}, ErrUnknownPalette
+	}
+	return p, nil
+}
+
+// PaletteNames returns the names of the registered palettes, sorted.
+func PaletteNames() []string {
+	palettesMutex.RLock()
+	defer palettesMutex.RUnlock()
+
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// hexPalette returns a palette whose colors
+// are given as 0xRRGGBB values.
+func hexPalette(name string, values ...uint32) Palette {
+	p := Palette{Name: name, Colors: make([]Color, len(values))}
+	for i, v := range values {
+		p.Colors[i] = NewRGBColor(byte(v>>16), byte(v>>8), byte(v))
+	}
+	return p
+}
+
+// The palettes of FastLED.
+func init() {
+	for _, p := range []Palette{
+		hexPalette("rainbow",
+			0xFF0000, 0xD52A00, 0xAB5500, 0xAB7F00, 0xABAB00, 0x56D500, 0x00FF00, 0x00D52A,
+			0x00AB55, 0x0056AA, 0x0000FF, 0x2A00D5, 0x5500AB, 0x7F0081, 0xAB0055, 0xD5002B),
+		hexPalette("party",
+			0x5500AB, 0x84007C, 0xB5004B, 0xE5001B, 0xE81700, 0xB84700, 0xAB7700, 0xABAB00,
+			0xAB5500, 0xDD2200, 0xF2000E, 0xC2003E, 0x8F0071, 0x5F00A1, 0x2F00D0, 0x0007F9),
+		hexPalette("cloud",
+			0x0000FF, 0x00008B, 0x00008B, 0x00008B, 0x00008B, 0x00008B, 0x00008B, 0x00008B,
+			0x0000FF, 0x00008B, 0x87CEEB, 0x87CEEB, 0xADD8E6, 0xFFFFFF, 0xADD8E6, 0x87CEEB),
+		hexPalette("lava",
+			0x000000, 0x800000, 0x000000, 0x800000, 0x8B0000, 0x8B0000, 0x800000, 0x8B0000,
+			0x8B0000, 0x8B0000, 0xFF0000, 0xFFA500, 0xFFFFFF, 0xFFA500, 0xFF0000, 0x8B0000),
+		hexPalette("ocean",
+			0x191970, 0x00008B, 0x191970, 0x000080, 0x00008B, 0x0000CD, 0x2E8B57, 0x008080,
+			0x5F9EA0, 0x0000FF, 0x008B8B, 0x6495ED, 0x7FFFD4, 0x2E8B57, 0x00FFFF, 0x87CEFA),
+		hexPalette("forest",
+			0x006400, 0x006400, 0x556B2F, 0x006400, 0x008000, 0x228B22, 0x6B8E23, 0x008000,
+			0x2E8B57, 0x66CDAA, 0x32CD32, 0x9ACD32, 0x90EE90, 0x7CFC00, 0x66CDAA, 0x228B22),
+		hexPalette("heat",
+			0x000000, 0x330000, 0x660000, 0x990000, 0xCC0000, 0xFF0000, 0xFF3300, 0xFF6600,
+			0xFF9900, 0xFFCC00, 0xFFFF00, 0xFFFF33, 0xFFFF66, 0xFFFF99, 0xFFFFCC, 0xFFFFFF),
+	} {
+		RegisterPalette(p)
+	}
+}
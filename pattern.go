@@ -30,6 +30,7 @@ import (
 	"image"
 	"image/draw"
 	"os"
+	"path/filepath"
 	"strings"
 
 	// Image decoding
@@ -43,14 +44,43 @@ import (
 )
 
 // A Frame represents a list of pixels.
+// The operations on a frame, such as Blend or Rotate, return a
+// new frame, and leave the frame they are called on untouched.
 type Frame []Pixel
 
+// copy returns a copy of the frame.
+func (f Frame) copy() Frame {
+	c := make(Frame, len(f))
+	copy(c, f)
+	return c
+}
+
 // A Pattern represents a list of frames.
 type Pattern []Frame
 
 // NewPatternFromImage returns a new pattern created from an image.
 // Types 'jpeg', 'png', 'gif' and 'bmp' are supported.
 func NewPatternFromImage(path string, pixelCount uint) (Pattern, error) {
+	src, err := NewImageSource(path, pixelCount)
+	if err != nil {
+		return nil, err
+	}
+	pattern := make(Pattern, 0, src.Len())
+
+	for {
+		f, ok := src.Next()
+		if !ok {
+			break
+		}
+		pattern = append(pattern, f)
+	}
+	return pattern, nil
+}
+
+// NewImageSource returns a new frame source created from an image, whose
+// frames are the same as the ones of the pattern returned by
+// NewPatternFromImage, but are only computed when requested.
+func NewImageSource(path string, pixelCount uint) (SizedFrameSource, error) {
 	if pixelCount == 0 {
 		return nil, ErrNoPixels
 	}
@@ -74,23 +104,63 @@ func NewPatternFromImage(path string, pixelCount uint) (Pattern, error) {
 	rgba := image.NewRGBA(bounds)
 	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
 
-	// loop across the pixels and create frames
-	pattern := make(Pattern, bounds.Dx())
+	return &imageSource{
+		rgba:       rgba,
+		pixelCount: pixelCount,
+	}, nil
+}
 
-	for x := 0; x < bounds.Dx(); x++ {
-		f := make(Frame, pixelCount)
-		for y := 0; y < bounds.Dy(); y++ {
-			r := rgba.Pix[rgba.PixOffset(x, y)]
-			g := rgba.Pix[rgba.PixOffset(x, y)+1]
-			b := rgba.Pix[rgba.PixOffset(x, y)+2]
+// imageSource is a FrameSource whose frames
+// are the columns of an image.
+type imageSource struct {
+	rgba       *image.RGBA
+	pixelCount uint
+	x          int
+}
 
-			f[y] = Pixel{
-				Color: NewRGBColor(brightnessCorrect(r, g, b)),
-			}
+func (is *imageSource) Next() (Frame, bool) {
+	bounds := is.rgba.Bounds()
+	if is.x >= bounds.Dx() {
+		return nil, false
+	}
+	f := make(Frame, is.pixelCount)
+
+	for y := 0; y < bounds.Dy(); y++ {
+		r := is.rgba.Pix[is.rgba.PixOffset(is.x, y)]
+		g := is.rgba.Pix[is.rgba.PixOffset(is.x, y)+1]
+		b := is.rgba.Pix[is.rgba.PixOffset(is.x, y)+2]
+
+		f[y] = Pixel{
+			Color: NewRGBColor(r, g, b),
 		}
-		pattern[x] = f
 	}
-	return pattern, nil
+	is.x++
+
+	return f, true
+}
+
+func (is *imageSource) Reset() {
+	is.x = 0
+}
+
+func (is *imageSource) Len() int {
+	return is.rgba.Bounds().Dx()
+}
+
+// NewAnimationFromImage returns a new animation played once, whose
+// pattern is created from an image like NewPatternFromImage does.
+// The frames are the columns of the image, so the delays of a GIF
+// are ignored. Use NewAnimationFromGIF to play the images of a GIF.
+func NewAnimationFromImage(path string, pixelCount uint) (*Animation, error) {
+	pattern, err := NewPatternFromImage(path, pixelCount)
+	if err != nil {
+		return nil, err
+	}
+	return &Animation{
+		Name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Repeat:  1,
+		Pattern: pattern,
+	}, nil
 }
 
 // readImage open a file and return an image.
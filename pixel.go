@@ -39,8 +39,8 @@ const (
 	HEX6DigitsForm = "%02x%02x%02x"
 )
 
-// Exponents are the factors used to convert a color
-// from the screen space to the LED space.
+// Exponents are the factors used to convert a color from the
+// screen space to the LED space, by DefaultColorCorrection.
 var (
 	RedExponent   = 1.8
 	GreenExponent = 1.8
@@ -60,20 +60,12 @@ type Color struct {
 }
 
 // NewRGBColor returns a new Color from its RGB representation.
+// The color is kept as is, and corrected when it is rendered
+// on the LED strip, see SetColorCorrection.
 func NewRGBColor(r, g, b byte) Color {
-	r, g, b = brightnessCorrect(r, g, b)
 	return Color{R: r, G: g, B: b}
 }
 
-// brightnessCorrect performs a rough brightness correction
-// on a given RGB color triplet.
-func brightnessCorrect(r, g, b byte) (byte, byte, byte) {
-	f := func(v byte, exp float64) byte {
-		return byte(255 * math.Pow(float64(v)/255.0, exp))
-	}
-	return f(r, RedExponent), f(g, GreenExponent), f(b, BlueExponent)
-}
-
 // NewNamedColor returns a new color from its name.
 // Supported names are from the package "colornames",
 // see https://godoc.org/golang.org/x/image/colornames
@@ -115,15 +107,9 @@ func NewHEXColor(color string) (Color, error) {
 	return NewRGBColor(r, g, b), nil
 }
 
-// clampedRGBTriplet returns the pixel data triplet in RGB format,
-// with its values clamped to 0-254 to avoid confusion with the LED
-// strip's control header (0xFF).
-func (p Pixel) clampedRGBTriplet() []byte {
-	return []byte{
-		clamp(p.Color.R),
-		clamp(p.Color.G),
-		clamp(p.Color.B),
-	}
+// rgbTriplet returns the pixel data triplet in RGB format.
+func (p Pixel) rgbTriplet() []byte {
+	return []byte{p.Color.R, p.Color.G, p.Color.B}
 }
 
 func clamp(v byte) byte {
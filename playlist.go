@@ -0,0 +1,324 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+)
+
+// Loop mode constants.
+const (
+	// LoopNone plays the entries of a playlist once.
+	LoopNone LoopMode = iota
+	// LoopAll plays the entries of a playlist indefinitely.
+	LoopAll
+	// LoopShuffle plays the entries of a playlist indefinitely,
+	// in a random order that changes after each cycle.
+	LoopShuffle
+)
+
+// LoopMode represents the way the entries of a playlist are sequenced.
+type LoopMode int
+
+var loopModeNames = map[LoopMode]string{
+	LoopNone:    "none",
+	LoopAll:     "all",
+	LoopShuffle: "shuffle",
+}
+
+// String implements the fmt.Stringer interface.
+func (lm LoopMode) String() string {
+	if name, ok := loopModeNames[lm]; ok {
+		return name
+	}
+	return fmt.Sprintf("LoopMode(%d)", int(lm))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (lm LoopMode) MarshalText() ([]byte, error) {
+	if _, ok := loopModeNames[lm]; !ok {
+		return nil, fmt.Errorf("unknown loop mode %d", int(lm))
+	}
+	return []byte(lm.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (lm *LoopMode) UnmarshalText(text []byte) error {
+	for mode, name := range loopModeNames {
+		if name == string(text) {
+			*lm = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown loop mode %q", text)
+}
+
+// A Playlist chains several animations.
+type Playlist struct {
+	Name    string          `json:"name"`
+	Loop    LoopMode        `json:"loop"`
+	Entries []PlaylistEntry `json:"entries"`
+}
+
+// A PlaylistEntry is an animation of a playlist. The animation is either
+// embedded, or loaded from a file whose path is relative to the one of the
+// playlist file. Repeat overrides the number of repetitions of the
+// animation, unless it is null. An animation without repetitions is
+// played once. The transition, if any, is played before the animation.
+type PlaylistEntry struct {
+	File       string      `json:"file,omitempty"`
+	Animation  *Animation  `json:"animation,omitempty"`
+	Repeat     int         `json:"repeat,omitempty"`
+	Transition *Transition `json:"transition,omitempty"`
+}
+
+// NewPlaylistFromFile creates a new Playlist instance from a file.
+// The playlist file must use JSON as its marshalling format.
+// The animations of the entries that reference a file are loaded.
+func NewPlaylistFromFile(path string) (*Playlist, error) {
+	pl := Playlist{}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &pl); err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(path)
+
+	for i, e := range pl.Entries {
+		if e.Animation != nil {
+			continue
+		}
+		if e.File == "" {
+			return nil, ErrEmptyPlaylistEntry
+		}
+		file := e.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		anim, err := NewAnimationFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pl.Entries[i].Animation = anim
+	}
+	return &pl, nil
+}
+
+// SaveToFile marshall a Playlist to JSON format and write it
+// to a file. The animations of the entries that reference
+// a file are not embedded.
+func (pl Playlist) SaveToFile(path string) error {
+	entries := make([]PlaylistEntry, len(pl.Entries))
+
+	for i, e := range pl.Entries {
+		if e.File != "" {
+			e.Animation = nil
+		}
+		entries[i] = e
+	}
+	pl.Entries = entries
+
+	data, err := json.Marshal(pl)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// order returns the order in which the entries
+// of the playlist are played for a cycle.
+func (pl *Playlist) order() []int {
+	if pl.Loop == LoopShuffle {
+		return rand.Perm(len(pl.Entries))
+	}
+	order := make([]int, len(pl.Entries))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// PlayPlaylist plays the animations of a playlist with the LED strip.
+// If an animation is already being played, it is stopped in favor of
+// the playlist. The playlist can be paused, resumed or stopped like
+// an animation, and its entries skipped with Next() and Previous().
+// Each animation is played with its own speed and frame durations.
+// The entries that cannot be played, such as an effect with invalid
+// parameters, are skipped and reported like the frames that cannot be
+// rendered. If no entry of a cycle can be played, the playback ends
+// with the error of the last one.
+func (bt *BlinkyTape) PlayPlaylist(pl *Playlist) *Playback {
+	return bt.PlayPlaylistContext(context.Background(), pl)
+}
+
+// PlayPlaylistContext is like PlayPlaylist, but the
+// playlist is stopped when the context is done.
+func (bt *BlinkyTape) PlayPlaylistContext(ctx context.Context, pl *Playlist) *Playback {
+	if len(pl.Entries) == 0 {
+		return donePlayback(nil)
+	}
+	sess := bt.start(ctx, StatusRunning)
+	sess.playlist = true
+
+	go bt.playlistLoop(sess, pl)
+	return sess.Playback
+}
+
+// Next skips to the next animation of the playlist being played.
+// If there is no playlist being played, do nothing.
+func (bt *BlinkyTape) Next() {
+	bt.skipEntry(loopNext)
+}
+
+// Previous goes back to the previous animation of the playlist
+// being played. If there is no playlist being played, do nothing.
+func (bt *BlinkyTape) Previous() {
+	bt.skipEntry(loopPrevious)
+}
+
+func (bt *BlinkyTape) skipEntry(res loopResult) {
+	if s, status := bt.current(); status == StatusRunning && s.playlist {
+		select {
+		case s.skip <- res:
+		case <-s.done:
+		}
+	}
+}
+
+func (bt *BlinkyTape) playlistLoop(sess *session, pl *Playlist) {
+	defer bt.end(sess)
+
+	s := newScheduler(FrameCatchUp)
+	bt.updateStats(s)
+
+	order := pl.order()
+	i := 0
+	// number of frames played during the current cycle,
+	// and error of the last entry that could not be played
+	played := 0
+	var failed error
+
+loop:
+	for {
+		if sess.ctx.Err() != nil {
+			break
+		}
+		if i >= len(order) {
+			// avoid spinning indefinitely on a
+			// playlist whose entries have no frames
+			if played == 0 && failed != nil {
+				sess.stop(failed)
+			}
+			if pl.Loop == LoopNone || played == 0 {
+				break
+			}
+			order = pl.order()
+			i = 0
+			played = 0
+			failed = nil
+		} else if i < 0 {
+			if pl.Loop == LoopNone {
+				i = 0
+			} else {
+				i = len(order) - 1
+			}
+		}
+		res, n, err := bt.playEntry(sess, pl.Entries[order[i]], s)
+		played += n
+		if err != nil {
+			failed = err
+		}
+
+		switch res {
+		case loopStopped:
+			break loop
+		case loopPrevious:
+			s.resync()
+			i--
+		case loopNext:
+			s.resync()
+			i++
+		default:
+			i++
+		}
+	}
+}
+
+// playEntry plays the transition and the animation of a playlist entry.
+// It returns the result of the loop, the number of frames played, and
+// the error that prevented the entry from being played, if any, which
+// is reported like the error of a frame. An animation with a null
+// number of repetitions is played once.
+func (bt *BlinkyTape) playEntry(sess *session, e PlaylistEntry, s *scheduler) (loopResult, int, error) {
+	a := e.Animation
+	if a == nil {
+		return loopDone, 0, bt.entryFailed(sess, s, ErrEmptyPlaylistEntry)
+	}
+	src, err := a.source(bt.PixelCount)
+	if err != nil {
+		return loopDone, 0, bt.entryFailed(sess, s, err)
+	}
+	first, ok := firstFrame(src)
+	if !ok {
+		return loopDone, 0, nil
+	}
+	played := 0
+	if e.Transition != nil {
+		p, durations := e.Transition.Pattern(bt.CurrentState(), first)
+		res, n := bt.playSource(sess, p.Source(), sliceDuration(durations), s)
+		if res != loopDone {
+			return res, n, nil
+		}
+		played = n
+	}
+	repeat := a.Repeat
+	if e.Repeat != 0 {
+		repeat = e.Repeat
+	}
+	if repeat == 0 {
+		repeat = 1
+	}
+	res, n := bt.repeatSource(sess, src, repeat, a.frameDuration(a.Delay()), s)
+	return res, played + n, nil
+}
+
+// entryFailed accounts the error of an entry that cannot be
+// played in the statistics, reports it, and returns it.
+func (bt *BlinkyTape) entryFailed(sess *session, s *scheduler, err error) error {
+	s.errors++
+	s.lastErr = err
+	bt.updateStats(s)
+	bt.emitError(sess, 0, err)
+
+	return err
+}
@@ -0,0 +1,69 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPlaylistEntryErrors(t *testing.T) {
+	bt, _ := newTestTape(t, 2)
+	defer bt.Close()
+
+	bad := &Animation{Repeat: 1, Effect: &EffectConfig{Name: "unknown"}}
+	good := &Animation{Repeat: 1, Speed: 1000, Pattern: Pattern{testFrame(2)}}
+
+	// the entries that cannot be played are skipped
+	pl := &Playlist{Entries: []PlaylistEntry{{Animation: bad}, {Animation: good}}}
+	if err := bt.PlayPlaylist(pl).Wait(); err != nil {
+		t.Errorf("playlist ended with %v, want nil", err)
+	}
+	if stats := bt.Stats(); stats.Errors != 1 || stats.LastError != ErrUnknownEffect {
+		t.Errorf("stats report %d errors and %v, want 1 and %v", stats.Errors, stats.LastError, ErrUnknownEffect)
+	}
+	// a playlist whose entries all fail ends with the error,
+	// instead of looping indefinitely
+	pl = &Playlist{Loop: LoopAll, Entries: []PlaylistEntry{{Animation: bad}, {}}}
+	if err := bt.PlayPlaylist(pl).Wait(); err != ErrEmptyPlaylistEntry {
+		t.Errorf("playlist ended with %v, want %v", err, ErrEmptyPlaylistEntry)
+	}
+}
+
+func TestPlayPlaylistContext(t *testing.T) {
+	bt, _ := newTestTape(t, 2)
+	defer bt.Close()
+
+	anim := &Animation{Repeat: -1, Speed: 1000, Pattern: Pattern{testFrame(2)}}
+	pl := &Playlist{Loop: LoopAll, Entries: []PlaylistEntry{{Animation: anim}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := bt.PlayPlaylistContext(ctx, pl).Wait(); err != context.DeadlineExceeded {
+		t.Errorf("playlist ended with %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "time"
+
+// ReconnectDefaultInterval is the default delay between two
+// attempts to reopen the transport of a LED strip.
+const ReconnectDefaultInterval = time.Second
+
+// An OpenFunc opens the transport of a LED strip.
+type OpenFunc func() (Transport, error)
+
+// SerialOpener returns an OpenFunc that opens
+// the serial port with the given name.
+func SerialOpener(portName string) OpenFunc {
+	return func() (Transport, error) {
+		return NewSerialTransport(portName)
+	}
+}
+
+// ReconnectConfig represents the configuration of the
+// automatic reconnection of a LED strip.
+type ReconnectConfig struct {
+	// Open reopens the transport of the LED strip, it is required
+	Open OpenFunc
+	// Interval is the delay between two attempts to reopen
+	// the transport, ReconnectDefaultInterval if null
+	Interval time.Duration
+}
+
+// SetReconnect enables the automatic reconnection of the LED strip,
+// or disables it if the configuration is nil. Once enabled, when a
+// write to the LED strip fails, its transport is closed and reopened
+// in the background until it succeeds. The control header is then
+// sent again, followed by the last state rendered. Meanwhile, the
+// writes fail with ErrDisconnected, and an animation being played
+// keeps running according to its error policy. A configuration
+// without Open function is rejected with ErrNilOpenFunc.
+func (bt *BlinkyTape) SetReconnect(cfg *ReconnectConfig) error {
+	if cfg != nil && cfg.Open == nil {
+		return ErrNilOpenFunc
+	}
+	bt.writeMutex.Lock()
+	defer bt.writeMutex.Unlock()
+
+	if cfg != nil {
+		c := *cfg
+		if c.Interval <= 0 {
+			c.Interval = ReconnectDefaultInterval
+		}
+		cfg = &c
+	}
+	bt.reconnect = cfg
+
+	return nil
+}
+
+// Connected returns whether the transport of the LED strip is
+// usable, or being reopened after a failure.
+func (bt *BlinkyTape) Connected() bool {
+	bt.writeMutex.Lock()
+	defer bt.writeMutex.Unlock()
+	return !bt.reconnecting
+}
+
+// timeout is implemented by the errors of
+// the writes that exceeded their deadline.
+type timeout interface {
+	Timeout() bool
+}
+
+// failed handles the failure of a write to the transport, and starts
+// the reconnection if it is enabled. A write that exceeded its
+// deadline doesn't mean that the transport is broken.
+// The caller must hold the write mutex.
+func (bt *BlinkyTape) failed(err error) {
+	if t, ok := err.(timeout); ok && t.Timeout() {
+		return
+	}
+	if bt.reconnect == nil || bt.reconnecting {
+		return
+	}
+	bt.reconnecting = true
+	go bt.reconnectLoop(*bt.reconnect, bt.getTransport())
+}
+
+// reconnectLoop closes the broken transport, and reopens a new one
+// until it succeeds, or the LED strip is closed.
+func (bt *BlinkyTape) reconnectLoop(cfg ReconnectConfig, broken Transport) {
+	broken.Close()
+
+	for {
+		select {
+		case <-bt.closed:
+			return
+		case <-time.After(cfg.Interval):
+		}
+		t, err := cfg.Open()
+		if err != nil {
+			continue
+		}
+		bt.writeMutex.Lock()
+		err = bt.restore(t)
+		if err == nil {
+			bt.mutex.Lock()
+			bt.transport = t
+			bt.mutex.Unlock()
+			bt.reconnecting = false
+		}
+		bt.writeMutex.Unlock()
+
+		if err == nil {
+			return
+		}
+		t.Close()
+	}
+}
+
+// restore sends the control header to a reopened transport, like
+// NewBlinkyTape does, then the last state rendered on the LED strip.
+// The caller must hold the write mutex.
+func (bt *BlinkyTape) restore(t Transport) error {
+	select {
+	case <-bt.closed:
+		return ErrTransportClosed
+	default:
+	}
+	if _, err := t.Write([]byte{ControlHeader}); err != nil {
+		return err
+	}
+	if len(bt.lastRender) != 0 {
+		if _, err := t.Write(bt.lastRender); err != nil {
+			return err
+		}
+	}
+	return nil
+}
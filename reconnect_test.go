@@ -0,0 +1,89 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// brokenTransport is a transport whose writes always fail.
+type brokenTransport struct{}
+
+func (brokenTransport) Read(p []byte) (int, error)  { return 0, nil }
+func (brokenTransport) Write(p []byte) (int, error) { return 0, errors.New("broken pipe") }
+func (brokenTransport) Close() error                { return nil }
+func (brokenTransport) Flush() error                { return nil }
+
+func TestSetReconnectNilOpen(t *testing.T) {
+	bt, _ := newTestTape(t, 1)
+	defer bt.Close()
+
+	if err := bt.SetReconnect(&ReconnectConfig{}); err != ErrNilOpenFunc {
+		t.Errorf("SetReconnect() = %v, want %v", err, ErrNilOpenFunc)
+	}
+	if err := bt.SetReconnect(nil); err != nil {
+		t.Errorf("SetReconnect(nil) = %v, want nil", err)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	bt, _ := newTestTape(t, 1)
+	defer bt.Close()
+
+	vt := NewVirtualTape(1)
+	err := bt.SetReconnect(&ReconnectConfig{
+		Open:     func() (Transport, error) { return vt, nil },
+		Interval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt.mutex.Lock()
+	bt.transport = brokenTransport{}
+	bt.mutex.Unlock()
+
+	red := Color{R: 200}
+	if err := bt.SetColor(red); err != nil {
+		t.Fatal(err)
+	}
+	if err := bt.Render(); err == nil {
+		t.Fatal("Render() succeeded on a broken transport")
+	}
+	deadline := time.Now().Add(time.Second)
+	for !bt.Connected() {
+		if time.Now().After(deadline) {
+			t.Fatal("LED strip not reconnected")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := bt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if got := vt.Pixels()[0].Color; got != red {
+		t.Errorf("pixel = %v, want %v", got, red)
+	}
+}
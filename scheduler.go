@@ -0,0 +1,115 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "time"
+
+// AnimationStats represents the statistics of an animation.
+type AnimationStats struct {
+	// Frames is the number of frames rendered.
+	Frames uint
+	// Dropped is the number of frames dropped because they were late.
+	Dropped uint
+	// Errors is the number of failed attempts to render a frame,
+	// and LastError the error of the last one.
+	Errors    uint
+	LastError error
+	// Elapsed is the time elapsed since the start of the
+	// animation, not including the time spent in pause.
+	Elapsed time.Duration
+}
+
+// FPS returns the number of frames per second
+// achieved by the animation.
+func (as AnimationStats) FPS() float64 {
+	if as.Elapsed <= 0 {
+		return 0
+	}
+	return float64(as.Frames) / as.Elapsed.Seconds()
+}
+
+// A scheduler computes the deadlines of the frames of an animation
+// from its start, rather than from the rendering of the previous
+// frame, so that the time spent writing to the LED strip does not
+// accumulate over the frames.
+type scheduler struct {
+	policy            FramePolicy
+	start             time.Time
+	offset            time.Duration
+	rendered, dropped uint
+	errors            uint
+	lastErr           error
+}
+
+func newScheduler(policy FramePolicy) *scheduler {
+	return &scheduler{
+		policy: policy,
+		start:  time.Now(),
+	}
+}
+
+// next schedules a frame that lasts for the given duration, and
+// returns its deadline as an offset from the start of the animation.
+func (s *scheduler) next(d time.Duration) time.Duration {
+	s.offset += d
+	return s.offset
+}
+
+// late returns whether a frame that lasts for the given duration and
+// ends at the given deadline must be dropped. Frames that last for
+// no time are never dropped.
+func (s *scheduler) late(d, end time.Duration) bool {
+	if s.policy != FrameDrop || d == 0 {
+		return false
+	}
+	return time.Since(s.start) > end
+}
+
+// until returns the duration until the given deadline.
+func (s *scheduler) until(end time.Duration) time.Duration {
+	return s.start.Add(end).Sub(time.Now())
+}
+
+// resync discards the remaining time of the frame
+// being waited for, when it is interrupted.
+func (s *scheduler) resync() {
+	s.offset = time.Since(s.start)
+}
+
+// shift delays the start of the animation by the given
+// duration, typically the time spent in pause.
+func (s *scheduler) shift(d time.Duration) {
+	s.start = s.start.Add(d)
+}
+
+func (s *scheduler) stats() AnimationStats {
+	return AnimationStats{
+		Frames:    s.rendered,
+		Dropped:   s.dropped,
+		Errors:    s.errors,
+		LastError: s.lastErr,
+		Elapsed:   time.Since(s.start),
+	}
+}
@@ -0,0 +1,282 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+// Package server exposes a BlinkyTape LED strip over HTTP, so that
+// several processes can share the same device. The requests and
+// responses bodies are encoded in JSON.
+//
+// The endpoints are:
+//
+//	PUT  /color       set all pixels to the same color (Color)
+//	PUT  /pixels      set pixels from a list ([]Pixel)
+//	PUT  /pixel       set a pixel at a position (PixelRequest)
+//	PUT  /brightness  set the brightness, from 0 to 1 (number)
+//	POST /render      render the accumulated changes
+//	POST /reset       discard the accumulated changes
+//	POST /off         switch off the LED strip
+//	POST /play        play an animation (PlayRequest)
+//	POST /pause       pause the animation
+//	POST /resume      resume the animation
+//	POST /stop        stop the animation
+//	GET  /status      get the animation status (StatusResponse)
+//
+// Successful commands reply with the status 204 No Content, and
+// errors are described by an ErrorResponse.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	blinky "github.com/wI2L/blinkygo"
+)
+
+// MaxBodySize is the maximum size of a request body, in bytes.
+const MaxBodySize = 32 << 20
+
+// PixelRequest is the body of a request to set a pixel at a position.
+type PixelRequest struct {
+	Pixel    blinky.Pixel `json:"pixel"`
+	Position uint         `json:"position"`
+}
+
+// PlayRequest is the body of a request to play an animation.
+// The configuration is optional, and its delay is expressed
+// in the format accepted by time.ParseDuration. Without a delay,
+// or with a null one, the one of the animation is used.
+type PlayRequest struct {
+	Animation blinky.Animation `json:"animation"`
+	Config    *PlayConfig      `json:"config,omitempty"`
+}
+
+// PlayConfig is the configuration of an animation to play.
+type PlayConfig struct {
+	Repeat int    `json:"repeat"`
+	Delay  string `json:"delay,omitempty"`
+}
+
+// StatusResponse is the body of the response to a status request.
+// Errors is the number of frames of the last animation that failed
+// to render, and LastError the error of the last one.
+type StatusResponse struct {
+	Status    blinky.AnimationStatus `json:"status"`
+	Errors    uint                   `json:"errors"`
+	LastError string                 `json:"lastError,omitempty"`
+}
+
+// ErrorResponse is the body of the response to a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// A Server is an HTTP handler that controls a BlinkyTape.
+// Requests are processed one at a time.
+type Server struct {
+	bt    *blinky.BlinkyTape
+	mutex sync.Mutex
+	mux   *http.ServeMux
+}
+
+// New returns a new Server that controls the given LED strip.
+func New(bt *blinky.BlinkyTape) *Server {
+	s := &Server{
+		bt:  bt,
+		mux: http.NewServeMux(),
+	}
+	s.handle("/color", http.MethodPut, s.setColor)
+	s.handle("/pixels", http.MethodPut, s.setPixels)
+	s.handle("/pixel", http.MethodPut, s.setPixelAt)
+	s.handle("/brightness", http.MethodPut, s.setBrightness)
+	s.handle("/render", http.MethodPost, s.command(s.bt.Render))
+	s.handle("/reset", http.MethodPost, s.command(s.bt.Reset))
+	s.handle("/off", http.MethodPost, s.command(s.bt.SwitchOff))
+	s.handle("/play", http.MethodPost, s.play)
+	s.handle("/pause", http.MethodPost, s.control(s.bt.Pause))
+	s.handle("/resume", http.MethodPost, s.control(s.bt.Resume))
+	s.handle("/stop", http.MethodPost, s.control(s.bt.Stop))
+	s.handle("/status", http.MethodGet, s.status)
+
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handlerFunc is a request handler that returns the status
+// code to reply with, and the body to encode, if any.
+type handlerFunc func(r *http.Request) (int, interface{})
+
+func (s *Server) handle(path, method string, h handlerFunc) {
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+				Error: fmt.Sprintf("method %s not allowed", r.Method),
+			})
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+		code, body := s.serve(h, r)
+		writeJSON(w, code, body)
+	})
+}
+
+// serve calls a handler, so that requests are processed one at a
+// time. The mutex is released even if the handler panics.
+func (s *Server) serve(h handlerFunc, r *http.Request) (int, interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return h(r)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// errorResponse returns the status code and the body
+// that describes an error returned by the LED strip.
+func errorResponse(err error) (int, interface{}) {
+	code := http.StatusInternalServerError
+
+	switch err.(type) {
+	case blinky.RangeError, blinky.PixelError:
+		code = http.StatusBadRequest
+	}
+	switch err {
+	case blinky.ErrBusyPlaying:
+		code = http.StatusConflict
+	case blinky.ErrOutOfRange, blinky.ErrEmptyBuffer:
+		code = http.StatusBadRequest
+	}
+	return code, ErrorResponse{Error: err.Error()}
+}
+
+func badRequest(err error) (int, interface{}) {
+	return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+}
+
+// command returns a handler that calls a method of the LED strip.
+func (s *Server) command(fn func() error) handlerFunc {
+	return func(r *http.Request) (int, interface{}) {
+		if err := fn(); err != nil {
+			return errorResponse(err)
+		}
+		return http.StatusNoContent, nil
+	}
+}
+
+// control returns a handler that calls an animation control
+// method of the LED strip.
+func (s *Server) control(fn func()) handlerFunc {
+	return func(r *http.Request) (int, interface{}) {
+		fn()
+		return http.StatusNoContent, nil
+	}
+}
+
+func (s *Server) setColor(r *http.Request) (int, interface{}) {
+	var c blinky.Color
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		return badRequest(err)
+	}
+	return s.command(func() error { return s.bt.SetColor(c) })(r)
+}
+
+func (s *Server) setPixels(r *http.Request) (int, interface{}) {
+	var pixels []blinky.Pixel
+	if err := json.NewDecoder(r.Body).Decode(&pixels); err != nil {
+		return badRequest(err)
+	}
+	return s.command(func() error { return s.bt.SetPixels(pixels) })(r)
+}
+
+func (s *Server) setPixelAt(r *http.Request) (int, interface{}) {
+	var req PixelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return badRequest(err)
+	}
+	return s.command(func() error { return s.bt.SetPixelAt(&req.Pixel, req.Position) })(r)
+}
+
+func (s *Server) setBrightness(r *http.Request) (int, interface{}) {
+	var b float64
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		return badRequest(err)
+	}
+	return s.command(func() error { return s.bt.SetBrightness(b) })(r)
+}
+
+func (s *Server) play(r *http.Request) (int, interface{}) {
+	var req PlayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return badRequest(err)
+	}
+	var cfg *blinky.AnimationConfig
+
+	if req.Config != nil {
+		cfg = &blinky.AnimationConfig{Repeat: req.Config.Repeat}
+		if req.Config.Delay != "" {
+			d, err := time.ParseDuration(req.Config.Delay)
+			if err != nil {
+				return badRequest(err)
+			}
+			cfg.Delay = d
+		}
+	}
+	pb := s.bt.Play(&req.Animation, cfg)
+
+	// the playback is already over if the
+	// pattern of the animation cannot be generated
+	select {
+	case <-pb.Done():
+		if err := pb.Err(); err != nil {
+			return badRequest(err)
+		}
+	default:
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) status(r *http.Request) (int, interface{}) {
+	resp := StatusResponse{Status: s.bt.Status()}
+
+	stats := s.bt.Stats()
+	resp.Errors = stats.Errors
+	if stats.LastError != nil {
+		resp.LastError = stats.LastError.Error()
+	}
+	return http.StatusOK, resp
+}
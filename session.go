@@ -0,0 +1,177 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"context"
+	"sync"
+)
+
+// A session is an animation loop, or a stream of frames, running on
+// a LED strip. Only one session runs at a time: starting a new one
+// stops the current one. The controls of the session are sent through
+// its channels, and it is stopped by cancelling its context.
+type session struct {
+	*Playback
+	ctx      context.Context
+	cancel   context.CancelFunc
+	once     sync.Once
+	pause    chan chan struct{}
+	resume   chan chan struct{}
+	skip     chan loopResult
+	playlist bool
+	repeat   int
+	onError  ErrorPolicy
+	retries  uint
+}
+
+// A Playback is a handle on an animation played with the LED strip,
+// that reports when it ends, and why.
+type Playback struct {
+	done chan struct{}
+	err  error
+}
+
+// donePlayback returns a playback that is already over.
+func donePlayback(err error) *Playback {
+	pb := &Playback{done: make(chan struct{}), err: err}
+	close(pb.done)
+	return pb
+}
+
+// Done returns a channel that is closed when the playback is over.
+func (pb *Playback) Done() <-chan struct{} {
+	return pb.done
+}
+
+// Wait blocks until the playback is over, and returns its error.
+func (pb *Playback) Wait() error {
+	<-pb.done
+	return pb.err
+}
+
+// Err returns the error that ended the playback. It is ErrStopped if
+// it was stopped, or superseded by another one, the error of its
+// context if it is done, and the error of the render of a frame if
+// it was aborted by its error policy. It returns nil if the playback
+// is not over, or if all its frames were played.
+func (pb *Playback) Err() error {
+	select {
+	case <-pb.done:
+		return pb.err
+	default:
+		return nil
+	}
+}
+
+// start stops the current session, if any, and returns a new one bound
+// to the given context, which becomes the current session of the LED
+// strip with the given status.
+func (bt *BlinkyTape) start(ctx context.Context, status AnimationStatus) *session {
+	ctx, cancel := context.WithCancel(ctx)
+
+	s := &session{
+		Playback: &Playback{done: make(chan struct{})},
+		ctx:      ctx,
+		cancel:   cancel,
+		pause:    make(chan chan struct{}),
+		resume:   make(chan chan struct{}),
+		skip:     make(chan loopResult),
+	}
+	bt.mutex.Lock()
+	prev := bt.session
+	bt.session = s
+	bt.status = status
+	bt.mutex.Unlock()
+
+	// wait for the previous session to return, so
+	// that both never write to the LED strip at once
+	if prev != nil {
+		prev.stop(ErrStopped)
+		<-prev.done
+	}
+	bt.emit(s, EventStarted)
+	return s
+}
+
+// end marks a session as done. If it is still the current session
+// of the LED strip, the status of the LED strip is reset.
+func (bt *BlinkyTape) end(s *session) {
+	bt.mutex.Lock()
+	if bt.session == s {
+		bt.session = nil
+		bt.status = StatusStopped
+	}
+	bt.mutex.Unlock()
+
+	// the context is only done if the session
+	// was stopped before the end of its frames
+	s.stop(s.ctx.Err())
+
+	if s.err != nil {
+		bt.emit(s, EventStopped)
+	} else {
+		bt.emit(s, EventFinished)
+	}
+	close(s.done)
+}
+
+// stop cancels a session, and records the reason,
+// unless the session was already stopped.
+func (s *session) stop(err error) {
+	s.once.Do(func() {
+		s.err = err
+	})
+	s.cancel()
+}
+
+// current returns the current session of the
+// LED strip, if any, and the status of the strip.
+func (bt *BlinkyTape) current() (*session, AnimationStatus) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	return bt.session, bt.status
+}
+
+// updateStatus updates the status of the LED strip,
+// if the session is still its current session.
+func (bt *BlinkyTape) updateStatus(s *session, as AnimationStatus) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	if bt.session == s {
+		bt.status = as
+	}
+}
+
+// send sends a control to a session, and waits until the session
+// acknowledges it, unless the session is done meanwhile.
+func (s *session) send(c chan chan struct{}) {
+	ack := make(chan struct{})
+	select {
+	case c <- ack:
+		<-ack
+	case <-s.done:
+	}
+}
@@ -0,0 +1,173 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"context"
+	"time"
+)
+
+// A FrameSource provides the frames of an animation one after the
+// other, so that they don't have to be held in memory at once, and
+// can be computed on the fly.
+type FrameSource interface {
+	// Next returns the next frame of the source, and false
+	// once there are no more frames.
+	Next() (Frame, bool)
+	// Reset rewinds the source to its first frame.
+	Reset()
+}
+
+// A SizedFrameSource is a FrameSource that knows its number of frames.
+type SizedFrameSource interface {
+	FrameSource
+
+	// Len returns the number of frames of the source.
+	Len() int
+}
+
+// A TimedFrameSource is a FrameSource whose frames have their own
+// duration, such as the source of an animation.
+type TimedFrameSource interface {
+	FrameSource
+
+	// Duration returns the duration of the frame at the given index
+	// of the source. A null duration stands for the configured delay.
+	Duration(i int) time.Duration
+}
+
+// Source returns a FrameSource that provides the frames of the pattern.
+func (p Pattern) Source() SizedFrameSource {
+	return &patternSource{pattern: p}
+}
+
+// patternSource is a FrameSource backed by a pattern.
+type patternSource struct {
+	pattern Pattern
+	pos     int
+}
+
+func (ps *patternSource) Next() (Frame, bool) {
+	if ps.pos >= len(ps.pattern) {
+		return nil, false
+	}
+	f := ps.pattern[ps.pos]
+	ps.pos++
+
+	return f, true
+}
+
+func (ps *patternSource) Reset() {
+	ps.pos = 0
+}
+
+func (ps *patternSource) Len() int {
+	return len(ps.pattern)
+}
+
+// firstFrame returns the first frame of a source,
+// and leaves it rewound.
+func firstFrame(src FrameSource) (Frame, bool) {
+	src.Reset()
+	f, ok := src.Next()
+	src.Reset()
+
+	return f, ok
+}
+
+// A durationFunc returns the duration of the
+// frame at the given index of a source.
+type durationFunc func(i int) time.Duration
+
+// constantDuration returns a durationFunc that
+// returns the same duration for all frames.
+func constantDuration(d time.Duration) durationFunc {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// sliceDuration returns a durationFunc that returns the
+// durations of a slice, or a null duration past its end.
+func sliceDuration(durations []time.Duration) durationFunc {
+	return func(i int) time.Duration {
+		if i < len(durations) {
+			return durations[i]
+		}
+		return 0
+	}
+}
+
+// timedDuration returns a durationFunc that returns the durations
+// of the frames of a source, or the given delay for the frames
+// that don't have their own.
+func timedDuration(src FrameSource, delay time.Duration) durationFunc {
+	ts, ok := src.(TimedFrameSource)
+	if !ok {
+		return constantDuration(delay)
+	}
+	return func(i int) time.Duration {
+		if d := ts.Duration(i); d != 0 {
+			return d
+		}
+		return delay
+	}
+}
+
+// PlaySource plays the frames of a source with the LED strip, like Play
+// does for an animation. If the configuration is nil, the frames are played
+// once, and without delay, the frames use the default one. The source is
+// rewound before each repetition.
+// The frames of a TimedFrameSource are held for their own duration, if any.
+func (bt *BlinkyTape) PlaySource(src FrameSource, cfg *AnimationConfig) *Playback {
+	return bt.PlaySourceContext(context.Background(), src, cfg)
+}
+
+// PlaySourceContext is like PlaySource, but the playback
+// is stopped when the given context is done.
+func (bt *BlinkyTape) PlaySourceContext(ctx context.Context, src FrameSource, cfg *AnimationConfig) *Playback {
+	if cfg == nil {
+		cfg = &AnimationConfig{
+			Repeat: 1,
+			Delay:  AnimationDefaultDelay,
+		}
+	}
+	if cfg.Repeat == 0 {
+		return donePlayback(nil)
+	}
+	delay := cfg.Delay
+	if delay == 0 {
+		delay = AnimationDefaultDelay
+	}
+	return bt.play(ctx, loopConfig{
+		source:     src,
+		duration:   timedDuration(src, delay),
+		repeat:     cfg.Repeat,
+		policy:     cfg.Policy,
+		transition: cfg.Transition,
+		onError:    cfg.OnError,
+		retries:    cfg.Retries,
+	})
+}
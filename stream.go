@@ -0,0 +1,135 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import "context"
+
+// StreamStats represents the statistics of a stream of frames.
+type StreamStats struct {
+	// Received is the number of frames received.
+	Received uint
+	// Rendered is the number of frames rendered.
+	Rendered uint
+	// Dropped is the number of frames replaced by a more
+	// recent one before they could be rendered.
+	Dropped uint
+	// Failed is the number of frames that couldn't be rendered.
+	Failed uint
+}
+
+// Stream renders the frames received from a channel as they arrive, until
+// the channel is closed, the context is done, or Stop() is called. If the
+// frames arrive faster than they can be rendered, only the most recent one
+// is rendered and the others are dropped. If an animation is being played,
+// it is stopped in favor of the stream, and the state of the LED strip
+// can't be modified while streaming. It returns the statistics of the
+// stream, and the error of the context if it is done.
+func (bt *BlinkyTape) Stream(ctx context.Context, frames <-chan Frame) (StreamStats, error) {
+	sess := bt.start(ctx, StatusStreaming)
+	defer bt.end(sess)
+
+	var (
+		stats     StreamStats
+		pending   Frame
+		hasFrame  bool
+		rendering bool
+		done      = make(chan error, 1)
+	)
+	// finish accounts for the result of the render of a frame
+	finish := func(err error) {
+		if err != nil {
+			stats.Failed++
+		} else {
+			stats.Rendered++
+		}
+		rendering = false
+		bt.updateStreamStats(stats)
+	}
+	// the frame being rendered, if any, must be
+	// done before the LED strip can be used again
+	defer func() {
+		if rendering {
+			finish(<-done)
+		}
+	}()
+
+	for {
+		if hasFrame && !rendering {
+			f := pending
+			go func() {
+				done <- bt.renderFrame(sess.ctx, f)
+			}()
+			rendering = true
+			hasFrame = false
+		}
+		select {
+		case <-sess.ctx.Done():
+			// the session is also cancelled by Stop()
+			return stats, ctx.Err()
+		case err := <-done:
+			finish(err)
+		case f, ok := <-frames:
+			if !ok {
+				// render the last frame received before returning
+				if rendering {
+					finish(<-done)
+				}
+				if hasFrame {
+					finish(bt.renderFrame(sess.ctx, pending))
+				}
+				return stats, nil
+			}
+			stats.Received++
+			if hasFrame {
+				stats.Dropped++
+			}
+			pending, hasFrame = f, true
+			bt.updateStreamStats(stats)
+		}
+	}
+}
+
+// renderFrame renders a frame on the LED strip.
+func (bt *BlinkyTape) renderFrame(ctx context.Context, f Frame) error {
+	bt.clear()
+	if err := bt.setPixels(f); err != nil {
+		return err
+	}
+	return bt.render(ctx)
+}
+
+func (bt *BlinkyTape) updateStreamStats(stats StreamStats) {
+	bt.statsMutex.Lock()
+	defer bt.statsMutex.Unlock()
+	bt.streamStats = stats
+}
+
+// StreamStats returns the statistics of the last
+// stream of frames, or of the one in progress.
+func (bt *BlinkyTape) StreamStats() StreamStats {
+	bt.statsMutex.Lock()
+	defer bt.statsMutex.Unlock()
+	return bt.streamStats
+}
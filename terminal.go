@@ -0,0 +1,172 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ANSI escape sequences used to draw frames in a terminal.
+const (
+	ansiColorForm = "\x1b[38;2;%d;%d;%dm"
+	ansiReset     = "\x1b[0m"
+	ansiBlock     = "█"
+)
+
+// A Terminal draws the frames sent to it as rows of 24-bit ANSI colored
+// blocks, one block per pixel. It implements the Transport interface, so it
+// can be used in place of a LED strip to preview colors and animations.
+type Terminal struct {
+	w       io.Writer
+	mutex   sync.Mutex
+	decoder frameDecoder
+	pixels  Frame
+	closed  bool
+
+	// Inline indicates whether each frame should be drawn over the
+	// previous one, rather than on a new line.
+	Inline bool
+}
+
+// NewTerminal creates a new Terminal with the given number of pixels,
+// that draws frames to w. All pixels are initially black.
+func NewTerminal(w io.Writer, count uint) *Terminal {
+	return &Terminal{
+		w:      w,
+		pixels: make(Frame, count),
+	}
+}
+
+// Write decodes the data sent to the terminal, and draws the
+// state of the LED strip each time a control header is received.
+func (t *Terminal) Write(data []byte) (int, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		return 0, ErrTransportClosed
+	}
+	var err error
+
+	t.decoder.decode(data, func(f Frame) {
+		copy(t.pixels, f)
+		if err == nil {
+			err = t.draw()
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (t *Terminal) draw() error {
+	if t.Inline {
+		if _, err := io.WriteString(t.w, "\r"); err != nil {
+			return err
+		}
+	}
+	if err := WriteANSIFrame(t.w, t.pixels); err != nil {
+		return err
+	}
+	if !t.Inline {
+		if _, err := io.WriteString(t.w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read implements the Transport interface. A Terminal
+// never sends data back, so it always returns io.EOF.
+func (t *Terminal) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+// Flush implements the Transport interface. It does nothing
+// since the data written is drawn immediately.
+func (t *Terminal) Flush() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		return ErrTransportClosed
+	}
+	return nil
+}
+
+// Close closes the terminal. The underlying writer is not closed,
+// but when drawing inline, a final new line is written to it.
+func (t *Terminal) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+
+	if t.Inline {
+		_, err := io.WriteString(t.w, "\n")
+		return err
+	}
+	return nil
+}
+
+// WriteANSIFrame writes a frame to w as a row of 24-bit ANSI colored
+// blocks, one block per pixel. No new line is written after the row.
+func WriteANSIFrame(w io.Writer, f Frame) error {
+	var buf bytes.Buffer
+
+	for _, p := range f {
+		fmt.Fprintf(&buf, ansiColorForm, p.Color.R, p.Color.G, p.Color.B)
+		buf.WriteString(ansiBlock)
+	}
+	buf.WriteString(ansiReset)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// PreviewPattern writes each frame of a pattern to w on a new
+// line, waiting for the given delay between two frames.
+func PreviewPattern(w io.Writer, p Pattern, delay time.Duration) error {
+	for i, f := range p {
+		if i != 0 {
+			time.Sleep(delay)
+		}
+		if err := WriteANSIFrame(w, f); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
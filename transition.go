@@ -0,0 +1,281 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// TransitionDefaultStep is the default duration of each
+// intermediate frame generated by a transition.
+const TransitionDefaultStep time.Duration = 20 * time.Millisecond
+
+// Transition effect constants.
+const (
+	// TransitionCut switches to the next state immediately.
+	TransitionCut TransitionEffect = iota
+	// TransitionBlank switches off the LED strip for
+	// the duration of the transition.
+	TransitionBlank
+	// TransitionCrossfade blends the colors of the pixels
+	// from the current state to the next one.
+	TransitionCrossfade
+	// TransitionWipeLeft reveals the next state from the
+	// end of the LED strip towards its beginning.
+	TransitionWipeLeft
+	// TransitionWipeRight reveals the next state from the
+	// beginning of the LED strip towards its end.
+	TransitionWipeRight
+	// TransitionDissolve switches the pixels to the next
+	// state one by one, in a random order.
+	TransitionDissolve
+	// TransitionFadeThroughBlack fades the current state
+	// out to black, then fades the next state in.
+	TransitionFadeThroughBlack
+)
+
+// TransitionEffect represents the effect of a transition.
+type TransitionEffect int
+
+var transitionEffectNames = map[TransitionEffect]string{
+	TransitionCut:              "cut",
+	TransitionBlank:            "blank",
+	TransitionCrossfade:        "crossfade",
+	TransitionWipeLeft:         "wipe-left",
+	TransitionWipeRight:        "wipe-right",
+	TransitionDissolve:         "dissolve",
+	TransitionFadeThroughBlack: "fade-through-black",
+}
+
+// String implements the fmt.Stringer interface.
+func (te TransitionEffect) String() string {
+	if name, ok := transitionEffectNames[te]; ok {
+		return name
+	}
+	return fmt.Sprintf("TransitionEffect(%d)", int(te))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (te TransitionEffect) MarshalText() ([]byte, error) {
+	if _, ok := transitionEffectNames[te]; !ok {
+		return nil, fmt.Errorf("unknown transition effect %d", int(te))
+	}
+	return []byte(te.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (te *TransitionEffect) UnmarshalText(text []byte) error {
+	for effect, name := range transitionEffectNames {
+		if name == string(text) {
+			*te = effect
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown transition effect %q", text)
+}
+
+// Easing constants.
+const (
+	// EaseLinear progresses at a constant rate.
+	EaseLinear Easing = iota
+	// EaseIn starts slowly and accelerates.
+	EaseIn
+	// EaseOut starts quickly and decelerates.
+	EaseOut
+	// EaseInOut starts slowly, accelerates,
+	// then decelerates at the end.
+	EaseInOut
+)
+
+// Easing represents the curve followed by the progress of a transition.
+type Easing int
+
+var easingNames = map[Easing]string{
+	EaseLinear: "linear",
+	EaseIn:     "in",
+	EaseOut:    "out",
+	EaseInOut:  "in-out",
+}
+
+// String implements the fmt.Stringer interface.
+func (e Easing) String() string {
+	if name, ok := easingNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("Easing(%d)", int(e))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e Easing) MarshalText() ([]byte, error) {
+	if _, ok := easingNames[e]; !ok {
+		return nil, fmt.Errorf("unknown easing %d", int(e))
+	}
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *Easing) UnmarshalText(text []byte) error {
+	for easing, name := range easingNames {
+		if name == string(text) {
+			*e = easing
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown easing %q", text)
+}
+
+// Ease returns the eased progress for the linear progress t,
+// both ranging from 0 to 1.
+func (e Easing) Ease(t float64) float64 {
+	switch e {
+	case EaseIn:
+		return t * t
+	case EaseOut:
+		return t * (2 - t)
+	case EaseInOut:
+		return t * t * (3 - 2*t)
+	default:
+		return t
+	}
+}
+
+// A Transition describes how the LED strip switches from one state
+// to another. Its duration is expressed in milliseconds, and Steps
+// is the number of intermediate frames to generate. If Steps is null,
+// a frame is generated every TransitionDefaultStep.
+type Transition struct {
+	Effect   TransitionEffect `json:"effect"`
+	Duration uint             `json:"duration"`
+	Easing   Easing           `json:"easing,omitempty"`
+	Steps    uint             `json:"steps,omitempty"`
+}
+
+// Pattern returns the frames to render to transition from one frame to
+// another, along with their durations. The last frame of a transition
+// is the next state, except for the cut and blank effects. If the next
+// frame is shorter than the current one, its missing pixels keep the
+// state of the current frame.
+func (t Transition) Pattern(from, to Frame) (Pattern, []time.Duration) {
+	d := time.Duration(t.Duration) * time.Millisecond
+
+	switch t.Effect {
+	case TransitionCut:
+		return nil, nil
+	case TransitionBlank:
+		return Pattern{make(Frame, len(from))}, []time.Duration{d}
+	}
+
+	steps := t.Steps
+	if steps == 0 {
+		steps = uint(d / TransitionDefaultStep)
+	}
+	if steps == 0 {
+		steps = 1
+	}
+	// complete the next state with the
+	// missing pixels of the current one
+	next := from.copy()
+	copy(next, to)
+
+	var order []int
+	if t.Effect == TransitionDissolve {
+		order = rand.Perm(len(from))
+	}
+	pattern := make(Pattern, steps)
+	durations := make([]time.Duration, steps)
+
+	for i := range pattern {
+		progress := t.Easing.Ease(float64(i+1) / float64(steps))
+		pattern[i] = transitionFrame(t.Effect, from, next, progress, order)
+		durations[i] = d / time.Duration(steps)
+	}
+	return pattern, durations
+}
+
+// transitionFrame returns the intermediate frame of a transition
+// between two frames of the same length, at the given progress.
+func transitionFrame(effect TransitionEffect, from, to Frame, progress float64, order []int) Frame {
+	f := make(Frame, len(from))
+	n := float64(len(from))
+
+	for i := range f {
+		switch effect {
+		case TransitionCrossfade:
+			f[i].Color = from[i].Color.Lerp(to[i].Color, progress)
+		case TransitionWipeLeft:
+			f[i] = from[i]
+			if float64(i) >= (1-progress)*n {
+				f[i] = to[i]
+			}
+		case TransitionWipeRight:
+			f[i] = from[i]
+			if float64(i) < progress*n {
+				f[i] = to[i]
+			}
+		case TransitionDissolve:
+			f[i] = from[i]
+			if float64(order[i]) < progress*n {
+				f[i] = to[i]
+			}
+		case TransitionFadeThroughBlack:
+			if progress < 0.5 {
+				f[i].Color = from[i].Color.Lerp(Color{}, progress*2)
+			} else {
+				f[i].Color = Color{}.Lerp(to[i].Color, progress*2-1)
+			}
+		default:
+			f[i] = to[i]
+		}
+	}
+	return f
+}
+
+// TransitionTo transitions the LED strip from its current state to the
+// given frame, like an animation played once. If an animation is already
+// being played, it is stopped in favor of the transition, which can be
+// paused, resumed or stopped as well.
+func (bt *BlinkyTape) TransitionTo(f Frame, t Transition) *Playback {
+	return bt.TransitionToContext(context.Background(), f, t)
+}
+
+// TransitionToContext is like TransitionTo, but the
+// transition is stopped when the context is done.
+func (bt *BlinkyTape) TransitionToContext(ctx context.Context, f Frame, t Transition) *Playback {
+	bt.Stop()
+
+	p, durations := t.Pattern(bt.CurrentState(), f)
+	if t.Effect == TransitionCut || t.Effect == TransitionBlank {
+		p = append(p, f)
+		durations = append(durations, 0)
+	}
+	return bt.play(ctx, loopConfig{
+		source:   p.Source(),
+		duration: sliceDuration(durations),
+		repeat:   1,
+	})
+}
@@ -0,0 +1,59 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"io"
+	"time"
+
+	"github.com/tarm/serial"
+)
+
+// A Transport is the link used to send data to a LED strip.
+// The serial port opened by NewBlinkyTape is one of them, but any
+// implementation can be used, such as a TCP bridge, a pipe or an
+// in-memory fake.
+type Transport interface {
+	io.ReadWriteCloser
+
+	// Flush discards any data written to the transport
+	// but not yet transmitted.
+	Flush() error
+}
+
+// NewSerialTransport opens the serial port with the given
+// name and returns it as a Transport.
+func NewSerialTransport(portName string) (Transport, error) {
+	config := &serial.Config{
+		Name:        portName,
+		Baud:        115200,
+		ReadTimeout: time.Millisecond * 500,
+	}
+	port, err := serial.OpenPort(config)
+	if err != nil {
+		return nil, err
+	}
+	return port, nil
+}
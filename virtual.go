@@ -0,0 +1,188 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// A RenderedFrame is a frame rendered by a VirtualTape,
+// along with the time it was rendered at.
+type RenderedFrame struct {
+	Time   time.Time
+	Pixels Frame
+}
+
+// A VirtualTape is an in-memory LED strip that implements the Transport
+// interface. It decodes the byte stream sent by a BlinkyTape the same way
+// the real device does, and keeps the history of the rendered frames.
+// It is intended to be used for tests, or when no LED strip is attached.
+//
+// Note that the color values of the pixels received are clamped to 0-254,
+// since the value 0xFF is reserved for the control header. They are also
+// the values sent to the device, after the color correction of the
+// BlinkyTape, gamma included by default, and its brightness. Call
+// SetColorCorrection with nil to record the colors as they are set.
+type VirtualTape struct {
+	mutex   sync.Mutex
+	decoder frameDecoder
+	pixels  Frame
+	frames  []RenderedFrame
+	closed  bool
+}
+
+// NewVirtualTape creates a new VirtualTape with the given number of pixels.
+// All pixels are initially black.
+func NewVirtualTape(count uint) *VirtualTape {
+	return &VirtualTape{
+		pixels: make(Frame, count),
+	}
+}
+
+// Write decodes the data sent to the virtual LED strip. Each time a control
+// header is received, the current state of the strip is appended to the
+// history of rendered frames, unless no pixel was received since the
+// previous control header.
+func (vt *VirtualTape) Write(data []byte) (int, error) {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+
+	if vt.closed {
+		return 0, ErrTransportClosed
+	}
+	vt.decoder.decode(data, func(f Frame) {
+		// like the real device, pixels beyond the length
+		// of the strip are ignored, and the remaining pixels
+		// keep their previous state.
+		copy(vt.pixels, f)
+		vt.frames = append(vt.frames, RenderedFrame{
+			Time:   time.Now(),
+			Pixels: vt.pixels.copy(),
+		})
+	})
+	return len(data), nil
+}
+
+// Read implements the Transport interface. A VirtualTape
+// never sends data back, so it always returns io.EOF.
+func (vt *VirtualTape) Read(p []byte) (int, error) {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+
+	if vt.closed {
+		return 0, ErrTransportClosed
+	}
+	return 0, io.EOF
+}
+
+// Flush implements the Transport interface. It does nothing
+// since the data written is decoded immediately.
+func (vt *VirtualTape) Flush() error {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+
+	if vt.closed {
+		return ErrTransportClosed
+	}
+	return nil
+}
+
+// Close closes the virtual LED strip. Subsequent
+// reads and writes will return ErrTransportClosed.
+func (vt *VirtualTape) Close() error {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+
+	vt.closed = true
+	return nil
+}
+
+// Pixels returns a copy of the current state of the virtual LED strip.
+func (vt *VirtualTape) Pixels() Frame {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+	return vt.pixels.copy()
+}
+
+// Frames returns the history of the frames rendered
+// by the virtual LED strip, in chronological order.
+func (vt *VirtualTape) Frames() []RenderedFrame {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+
+	frames := make([]RenderedFrame, len(vt.frames))
+	copy(frames, vt.frames)
+
+	return frames
+}
+
+// FrameCount returns the number of frames rendered
+// by the virtual LED strip.
+func (vt *VirtualTape) FrameCount() int {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+	return len(vt.frames)
+}
+
+// Reset clears the history of rendered frames. The current
+// state of the virtual LED strip is left untouched.
+func (vt *VirtualTape) Reset() {
+	vt.mutex.Lock()
+	defer vt.mutex.Unlock()
+	vt.frames = nil
+}
+
+// frameDecoder decodes the byte stream sent to a LED strip,
+// composed of RGB triplets terminated by a control header.
+type frameDecoder struct {
+	triplet []byte
+	frame   Frame
+}
+
+// decode consumes the data and calls fn with the pixels received
+// each time a control header is encountered. Incomplete triplets
+// are kept until the next call, and discarded by a control header.
+func (d *frameDecoder) decode(data []byte, fn func(Frame)) {
+	for _, b := range data {
+		if b == ControlHeader {
+			if len(d.frame) != 0 {
+				fn(d.frame)
+			}
+			d.frame = d.frame[:0]
+			d.triplet = d.triplet[:0]
+			continue
+		}
+		d.triplet = append(d.triplet, b)
+
+		if len(d.triplet) == 3 {
+			d.frame = append(d.frame, Pixel{
+				Color: Color{R: d.triplet[0], G: d.triplet[1], B: d.triplet[2]},
+			})
+			d.triplet = d.triplet[:0]
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+	The MIT License
+
+	Copyright (c) 2016, William Poussier <william.poussier@gmail.com>
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in
+	all copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+	THE SOFTWARE.
+*/
+
+package blinkygo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVirtualTapeDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes [][]byte
+		want   []Frame
+	}{
+		{
+			name:   "single frame",
+			writes: [][]byte{{1, 2, 3, 4, 5, 6, 0xFF}},
+			want:   []Frame{{{Color: Color{1, 2, 3}}, {Color: Color{4, 5, 6}}, {}}},
+		},
+		{
+			name:   "triplets split across writes",
+			writes: [][]byte{{1, 2}, {3, 4}, {5, 6, 0xFF}},
+			want:   []Frame{{{Color: Color{1, 2, 3}}, {Color: Color{4, 5, 6}}, {}}},
+		},
+		{
+			name:   "partial triplet dropped at the header",
+			writes: [][]byte{{1, 2, 3, 4, 0xFF}, {5, 6, 7, 0xFF}},
+			want: []Frame{
+				{{Color: Color{1, 2, 3}}, {}, {}},
+				{{Color: Color{5, 6, 7}}, {}, {}},
+			},
+		},
+		{
+			name:   "pixels beyond the strip ignored",
+			writes: [][]byte{{1, 1, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4, 0xFF}},
+			want: []Frame{
+				{{Color: Color{1, 1, 1}}, {Color: Color{2, 2, 2}}, {Color: Color{3, 3, 3}}},
+			},
+		},
+		{
+			name:   "pixels not received keep their state",
+			writes: [][]byte{{1, 1, 1, 2, 2, 2, 0xFF, 9, 9, 9, 0xFF}},
+			want: []Frame{
+				{{Color: Color{1, 1, 1}}, {Color: Color{2, 2, 2}}, {}},
+				{{Color: Color{9, 9, 9}}, {Color: Color{2, 2, 2}}, {}},
+			},
+		},
+		{
+			name:   "empty frames not recorded",
+			writes: [][]byte{{0xFF, 0xFF}, {1, 2, 0xFF}},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		vt := NewVirtualTape(3)
+		for _, w := range tt.writes {
+			if n, err := vt.Write(w); err != nil || n != len(w) {
+				t.Fatalf("%s: Write() = %d, %v", tt.name, n, err)
+			}
+		}
+		var got []Frame
+		for _, f := range vt.Frames() {
+			got = append(got, f.Pixels)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: frames = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVirtualTapeClosed(t *testing.T) {
+	vt := NewVirtualTape(1)
+	vt.Close()
+
+	if _, err := vt.Write([]byte{0xFF}); err != ErrTransportClosed {
+		t.Errorf("Write() = %v, want %v", err, ErrTransportClosed)
+	}
+	if err := vt.Flush(); err != ErrTransportClosed {
+		t.Errorf("Flush() = %v, want %v", err, ErrTransportClosed)
+	}
+}
+
+func TestRender(t *testing.T) {
+	bt, vt := newTestTape(t, 3)
+	defer bt.Close()
+
+	red := NewRGBColor(200, 0, 0)
+	if err := bt.SetColor(red); err != nil {
+		t.Fatal(err)
+	}
+	if vt.FrameCount() != 0 {
+		t.Fatal("frame rendered before Render()")
+	}
+	if err := bt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	want := Frame{{Color: red}, {Color: red}, {Color: red}}
+	if got := vt.Pixels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("pixels = %v, want %v", got, want)
+	}
+	// the values 0xFF are reserved for the control header
+	blue := NewRGBColor(0, 0, 255)
+	if err := bt.SetPixelAt(&Pixel{Color: blue}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := bt.Render(); err != nil {
+		t.Fatal(err)
+	}
+	want[1].Color = Color{B: 254}
+	if got := vt.Pixels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("pixels = %v, want %v", got, want)
+	}
+	if n := vt.FrameCount(); n != 2 {
+		t.Errorf("%d frames rendered, want 2", n)
+	}
+	if err := bt.SetPixelAt(&Pixel{}, 3); err != ErrOutOfRange {
+		t.Errorf("SetPixelAt(3) = %v, want %v", err, ErrOutOfRange)
+	}
+	if err := bt.Render(); err != ErrEmptyBuffer {
+		t.Errorf("Render() = %v, want %v", err, ErrEmptyBuffer)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	bt, vt := newTestTape(t, 2)
+	defer bt.Close()
+
+	pattern := Pattern{testFrame(2), testFrame(2).Reverse()}
+	anim := &Animation{Pattern: pattern}
+
+	err := bt.Play(anim, &AnimationConfig{Repeat: 2, Delay: time.Millisecond}).Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]byte
+	for _, f := range vt.Frames() {
+		got = append(got, reds(f.Pixels))
+	}
+	want := [][]byte{{1, 2}, {2, 1}, {1, 2}, {2, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %v, want %v", got, want)
+	}
+	if stats := bt.Stats(); stats.Frames != 4 {
+		t.Errorf("stats report %d frames, want 4", stats.Frames)
+	}
+	if s := bt.Status(); s != StatusStopped {
+		t.Errorf("status = %v, want %v", s, StatusStopped)
+	}
+}